@@ -28,6 +28,28 @@ type Specification struct {
 	// The Instana API Token
 	ApiToken           string `json:"apiToken" split_words:"true" required:"true"`
 	InsecureSkipVerify bool   `json:"insecureSkipVerify" split_words:"true" default:"false"`
+	// Minimum severity ('info', 'warning' or 'critical') of open Instana events which prevent an experiment from starting
+	PreflightEventSeverityFilter string `json:"preflightEventSeverityFilter" split_words:"true" default:"critical"`
+	// Regular expressions matched against the service and endpoint label. If set, only matching endpoints are discovered
+	DiscoveryEndpointsInclude []string `json:"discoveryEndpointsInclude" split_words:"true" required:"false"`
+	// Regular expressions matched against the service and endpoint label. Matching endpoints are not discovered
+	DiscoveryEndpointsExclude []string `json:"discoveryEndpointsExclude" split_words:"true" required:"false"`
+	// Interval in which Steadybit fetches the application perspectives from the extension, like '1m'
+	DiscoveryApplicationsCallInterval string `json:"discoveryApplicationsCallInterval" split_words:"true" default:"1m"`
+	// Interval in which the extension refreshes the application perspectives from Instana
+	DiscoveryApplicationsRefreshInterval time.Duration `json:"discoveryApplicationsRefreshInterval" split_words:"true" default:"1m"`
+	// Page size used to fetch application perspectives from Instana
+	DiscoveryApplicationsPageSize int `json:"discoveryApplicationsPageSize" split_words:"true" default:"100"`
+	// If set, only application perspectives whose name contains this value are fetched from Instana
+	DiscoveryApplicationsNameFilter string `json:"discoveryApplicationsNameFilter" split_words:"true" required:"false"`
+	// Tags ('name' or 'name=value') which must all be part of the tag filter expression of a discovered application perspective.
+	// Instana can't filter the application perspectives by tags, so they are filtered by the extension after fetching them
+	// and their configurations. Use the name filter to reduce the load on the Instana API.
+	DiscoveryApplicationsTagFilter []string `json:"discoveryApplicationsTagFilter" split_words:"true" required:"false"`
+	// Instana infrastructure plugins, like 'host' or 'kubernetesDeployment', whose snapshots are discovered as infrastructure entities
+	DiscoveryInfrastructurePlugins []string `json:"discoveryInfrastructurePlugins" split_words:"true" default:"host,kubernetesCluster,kubernetesNamespace,kubernetesDeployment"`
+	// Interval in which ended maintenance windows created by the extension are deleted, '0' disables the cleanup
+	MaintenanceWindowReaperInterval time.Duration `json:"maintenanceWindowReaperInterval" split_words:"true" default:"5m"`
 }
 
 var (
@@ -77,6 +99,85 @@ func (s *Specification) GetSnapshotIds(_ context.Context, applicationPerspective
 	}
 }
 
+func (s *Specification) GetApplicationSnapshots(_ context.Context, applicationPerspectiveId string, plugin string) ([]types.Snapshot, error) {
+	return s.searchSnapshots(fmt.Sprintf("%s/api/infrastructure-monitoring/snapshots?query=entity.application.id:%s&plugin=%s&size=20000", s.BaseUrl, url.QueryEscape(applicationPerspectiveId), url.QueryEscape(plugin)))
+}
+
+func (s *Specification) GetSnapshots(_ context.Context, plugin string) ([]types.Snapshot, error) {
+	return s.searchSnapshots(fmt.Sprintf("%s/api/infrastructure-monitoring/snapshots?plugin=%s&size=20000", s.BaseUrl, url.QueryEscape(plugin)))
+}
+
+// CountSnapshots returns the number of infrastructure entities matching the Dynamic Focus Query.
+func (s *Specification) CountSnapshots(_ context.Context, query string) (int, error) {
+	snapshots, err := s.searchSnapshots(fmt.Sprintf("%s/api/infrastructure-monitoring/snapshots?query=%s&size=20000", s.BaseUrl, url.QueryEscape(query)))
+	if err != nil {
+		return 0, err
+	}
+	return len(snapshots), nil
+}
+
+func (s *Specification) searchSnapshots(requestUrl string) ([]types.Snapshot, error) {
+	responseBody, response, err := s.do(requestUrl, "GET", nil)
+	if err != nil {
+		log.Error().Str("url", requestUrl).Err(err).Msgf("Failed to get snapshots from Instana. Full response %+v", string(responseBody))
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		log.Error().Int("code", response.StatusCode).Err(err).Msgf("Unexpected response %+v", string(responseBody))
+		return nil, errors.New("unexpected response code")
+	}
+
+	var result types.SnapshotSearchResponse
+	if responseBody != nil {
+		err = json.Unmarshal(responseBody, &result)
+		if err != nil {
+			log.Error().Err(err).Str("body", string(responseBody)).Msgf("Failed to parse body")
+			return nil, err
+		}
+		if len(result.Items) == 20000 {
+			log.Warn().Str("url", requestUrl).Msg("There are more than 20000 snapshots. Only the first 20000 will be considered.")
+		}
+		return result.Items, nil
+	} else {
+		log.Error().Err(err).Msgf("Empty response body")
+		return nil, errors.New("empty response body")
+	}
+}
+
+func (s *Specification) GetSnapshotDetails(_ context.Context, snapshotIds []string) ([]types.SnapshotDetails, error) {
+	requestUrl := fmt.Sprintf("%s/api/infrastructure-monitoring/snapshots", s.BaseUrl)
+	b, err := json.Marshal(types.SnapshotDetailsRequest{SnapshotIds: snapshotIds})
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to marshal request")
+		return nil, err
+	}
+
+	responseBody, response, err := s.do(requestUrl, "POST", b)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to get snapshot details from Instana. Full response %+v", string(responseBody))
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		log.Error().Int("code", response.StatusCode).Err(err).Msgf("Unexpected response %+v", string(responseBody))
+		return nil, errors.New("unexpected response code")
+	}
+
+	var result types.SnapshotDetailsResponse
+	if responseBody != nil {
+		err = json.Unmarshal(responseBody, &result)
+		if err != nil {
+			log.Error().Err(err).Str("body", string(responseBody)).Msgf("Failed to parse body")
+			return nil, err
+		}
+		return result.Items, nil
+	} else {
+		log.Error().Err(err).Msgf("Empty response body")
+		return nil, errors.New("empty response body")
+	}
+}
+
 func (s *Specification) GetEvents(_ context.Context, from time.Time, to time.Time, eventTypeFilters []string) ([]types.Event, error) {
 	requestUrl := fmt.Sprintf("%s/api/events?from=%d&to=%d", s.BaseUrl, from.UnixMilli(), to.UnixMilli())
 	for _, eventTypeFilter := range eventTypeFilters {
@@ -107,9 +208,12 @@ func (s *Specification) GetEvents(_ context.Context, from time.Time, to time.Tim
 }
 
 func (s *Specification) GetApplicationPerspectives(_ context.Context, page int, pageSize int) (*types.ApplicationPerspectiveResponse, error) {
-	url := fmt.Sprintf("%s/api/application-monitoring/applications?page=%d&pageSize=%d", s.BaseUrl, page, pageSize)
+	requestUrl := fmt.Sprintf("%s/api/application-monitoring/applications?page=%d&pageSize=%d", s.BaseUrl, page, pageSize)
+	if s.DiscoveryApplicationsNameFilter != "" {
+		requestUrl = fmt.Sprintf("%s&nameFilter=%s", requestUrl, url.QueryEscape(s.DiscoveryApplicationsNameFilter))
+	}
 
-	responseBody, response, err := s.do(url, "GET", nil)
+	responseBody, response, err := s.do(requestUrl, "GET", nil)
 	if err != nil {
 		log.Error().Int("page", page).Int("pageSize", pageSize).Err(err).Msgf("Failed to get application perspectives from Instana. Full response %+v", string(responseBody))
 		return nil, err
@@ -134,6 +238,422 @@ func (s *Specification) GetApplicationPerspectives(_ context.Context, page int,
 	}
 }
 
+func (s *Specification) GetApplicationConfigs(_ context.Context) ([]types.ApplicationConfig, error) {
+	url := fmt.Sprintf("%s/api/application-monitoring/settings/application", s.BaseUrl)
+
+	responseBody, response, err := s.do(url, "GET", nil)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to get application perspective configurations from Instana. Full response %+v", string(responseBody))
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		log.Error().Int("code", response.StatusCode).Err(err).Msgf("Unexpected response %+v", string(responseBody))
+		return nil, errors.New("unexpected response code")
+	}
+
+	var result []types.ApplicationConfig
+	if responseBody != nil {
+		err = json.Unmarshal(responseBody, &result)
+		if err != nil {
+			log.Error().Err(err).Str("body", string(responseBody)).Msgf("Failed to parse body")
+			return nil, err
+		}
+		return result, nil
+	} else {
+		log.Error().Err(err).Msgf("Empty response body")
+		return nil, errors.New("empty response body")
+	}
+}
+
+func (s *Specification) GetServices(_ context.Context, page int, pageSize int) (*types.ServiceResponse, error) {
+	return s.getServices(fmt.Sprintf("%s/api/application-monitoring/services?page=%d&pageSize=%d", s.BaseUrl, page, pageSize))
+}
+
+func (s *Specification) GetApplicationServices(_ context.Context, applicationPerspectiveId string, page int, pageSize int) (*types.ServiceResponse, error) {
+	return s.getServices(fmt.Sprintf("%s/api/application-monitoring/applications/%s/services?page=%d&pageSize=%d", s.BaseUrl, applicationPerspectiveId, page, pageSize))
+}
+
+func (s *Specification) getServices(url string) (*types.ServiceResponse, error) {
+	responseBody, response, err := s.do(url, "GET", nil)
+	if err != nil {
+		log.Error().Str("url", url).Err(err).Msgf("Failed to get services from Instana. Full response %+v", string(responseBody))
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		log.Error().Int("code", response.StatusCode).Str("url", url).Err(err).Msgf("Unexpected response %+v", string(responseBody))
+		return nil, errors.New("unexpected response code")
+	}
+
+	var result types.ServiceResponse
+	if responseBody != nil {
+		err = json.Unmarshal(responseBody, &result)
+		if err != nil {
+			log.Error().Str("url", url).Err(err).Str("body", string(responseBody)).Msgf("Failed to parse body")
+			return nil, err
+		}
+		return &result, nil
+	} else {
+		log.Error().Str("url", url).Err(err).Msgf("Empty response body")
+		return nil, errors.New("empty response body")
+	}
+}
+
+func (s *Specification) GetEndpoints(_ context.Context, page int, pageSize int) (*types.EndpointResponse, error) {
+	url := fmt.Sprintf("%s/api/application-monitoring/endpoints?page=%d&pageSize=%d", s.BaseUrl, page, pageSize)
+
+	responseBody, response, err := s.do(url, "GET", nil)
+	if err != nil {
+		log.Error().Int("page", page).Int("pageSize", pageSize).Err(err).Msgf("Failed to get endpoints from Instana. Full response %+v", string(responseBody))
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		log.Error().Int("code", response.StatusCode).Int("page", page).Int("pageSize", pageSize).Err(err).Msgf("Unexpected response %+v", string(responseBody))
+		return nil, errors.New("unexpected response code")
+	}
+
+	var result types.EndpointResponse
+	if responseBody != nil {
+		err = json.Unmarshal(responseBody, &result)
+		if err != nil {
+			log.Error().Int("page", page).Int("pageSize", pageSize).Err(err).Str("body", string(responseBody)).Msgf("Failed to parse body")
+			return nil, err
+		}
+		return &result, nil
+	} else {
+		log.Error().Int("page", page).Int("pageSize", pageSize).Err(err).Msgf("Empty response body")
+		return nil, errors.New("empty response body")
+	}
+}
+
+func (s *Specification) GetApplicationMetrics(_ context.Context, request types.MetricsRequest) (*types.MetricsResponse, error) {
+	return s.getMetrics(fmt.Sprintf("%s/api/application-monitoring/metrics/applications", s.BaseUrl), request)
+}
+
+func (s *Specification) GetServiceMetrics(_ context.Context, request types.MetricsRequest) (*types.MetricsResponse, error) {
+	return s.getMetrics(fmt.Sprintf("%s/api/application-monitoring/metrics/services", s.BaseUrl), request)
+}
+
+func (s *Specification) GetEndpointMetrics(_ context.Context, request types.MetricsRequest) (*types.MetricsResponse, error) {
+	return s.getMetrics(fmt.Sprintf("%s/api/application-monitoring/metrics/endpoints", s.BaseUrl), request)
+}
+
+func (s *Specification) getMetrics(requestUrl string, request types.MetricsRequest) (*types.MetricsResponse, error) {
+	b, err := json.Marshal(request)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to marshal request")
+		return nil, err
+	}
+
+	responseBody, response, err := s.do(requestUrl, "POST", b)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to get metrics from Instana. Full response %+v", string(responseBody))
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		log.Error().Int("code", response.StatusCode).Err(err).Msgf("Unexpected response %+v", string(responseBody))
+		return nil, errors.New("unexpected response code")
+	}
+
+	var result types.MetricsResponse
+	if responseBody != nil {
+		err = json.Unmarshal(responseBody, &result)
+		if err != nil {
+			log.Error().Err(err).Str("body", string(responseBody)).Msgf("Failed to parse body")
+			return nil, err
+		}
+		return &result, nil
+	} else {
+		log.Error().Err(err).Msgf("Empty response body")
+		return nil, errors.New("empty response body")
+	}
+}
+
+func (s *Specification) GetInfrastructureMetrics(_ context.Context, request types.InfrastructureMetricsRequest) (*types.InfrastructureMetricsResponse, error) {
+	b, err := json.Marshal(request)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to marshal request")
+		return nil, err
+	}
+
+	responseBody, response, err := s.do(fmt.Sprintf("%s/api/infrastructure-monitoring/metrics", s.BaseUrl), "POST", b)
+	if err != nil {
+		log.Error().Str("plugin", request.Plugin).Err(err).Msgf("Failed to get infrastructure metrics from Instana. Full response %+v", string(responseBody))
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		log.Error().Int("code", response.StatusCode).Str("plugin", request.Plugin).Err(err).Msgf("Unexpected response %+v", string(responseBody))
+		return nil, errors.New("unexpected response code")
+	}
+
+	var result types.InfrastructureMetricsResponse
+	if responseBody != nil {
+		err = json.Unmarshal(responseBody, &result)
+		if err != nil {
+			log.Error().Err(err).Str("body", string(responseBody)).Msgf("Failed to parse body")
+			return nil, err
+		}
+		return &result, nil
+	} else {
+		log.Error().Err(err).Msgf("Empty response body")
+		return nil, errors.New("empty response body")
+	}
+}
+
+func (s *Specification) GetWebsites(_ context.Context) ([]types.Website, error) {
+	url := fmt.Sprintf("%s/api/website-monitoring/config", s.BaseUrl)
+
+	responseBody, response, err := s.do(url, "GET", nil)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to get websites from Instana. Full response %+v", string(responseBody))
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		log.Error().Int("code", response.StatusCode).Err(err).Msgf("Unexpected response %+v", string(responseBody))
+		return nil, errors.New("unexpected response code")
+	}
+
+	var result []types.Website
+	if responseBody != nil {
+		err = json.Unmarshal(responseBody, &result)
+		if err != nil {
+			log.Error().Err(err).Str("body", string(responseBody)).Msgf("Failed to parse body")
+			return nil, err
+		}
+		return result, nil
+	} else {
+		log.Error().Err(err).Msgf("Empty response body")
+		return nil, errors.New("empty response body")
+	}
+}
+
+func (s *Specification) GetMobileApps(_ context.Context) ([]types.MobileApp, error) {
+	url := fmt.Sprintf("%s/api/mobile-app-monitoring/config", s.BaseUrl)
+
+	responseBody, response, err := s.do(url, "GET", nil)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to get mobile apps from Instana. Full response %+v", string(responseBody))
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		log.Error().Int("code", response.StatusCode).Err(err).Msgf("Unexpected response %+v", string(responseBody))
+		return nil, errors.New("unexpected response code")
+	}
+
+	var result []types.MobileApp
+	if responseBody != nil {
+		err = json.Unmarshal(responseBody, &result)
+		if err != nil {
+			log.Error().Err(err).Str("body", string(responseBody)).Msgf("Failed to parse body")
+			return nil, err
+		}
+		return result, nil
+	} else {
+		log.Error().Err(err).Msgf("Empty response body")
+		return nil, errors.New("empty response body")
+	}
+}
+
+func (s *Specification) GetWebsiteMetrics(_ context.Context, request types.WebsiteMetricsRequest) (*types.WebsiteMetricsResponse, error) {
+	b, err := json.Marshal(request)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to marshal request")
+		return nil, err
+	}
+
+	responseBody, response, err := s.do(fmt.Sprintf("%s/api/website-monitoring/v2/metrics", s.BaseUrl), "POST", b)
+	if err != nil {
+		log.Error().Str("type", request.Type).Err(err).Msgf("Failed to get website metrics from Instana. Full response %+v", string(responseBody))
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		log.Error().Int("code", response.StatusCode).Str("type", request.Type).Err(err).Msgf("Unexpected response %+v", string(responseBody))
+		return nil, errors.New("unexpected response code")
+	}
+
+	var result types.WebsiteMetricsResponse
+	if responseBody != nil {
+		err = json.Unmarshal(responseBody, &result)
+		if err != nil {
+			log.Error().Err(err).Str("body", string(responseBody)).Msgf("Failed to parse body")
+			return nil, err
+		}
+		return &result, nil
+	} else {
+		log.Error().Err(err).Msgf("Empty response body")
+		return nil, errors.New("empty response body")
+	}
+}
+
+func (s *Specification) GetSloConfigs(_ context.Context, page int, pageSize int) (*types.SloConfigResponse, error) {
+	url := fmt.Sprintf("%s/api/settings/slo?page=%d&pageSize=%d", s.BaseUrl, page, pageSize)
+
+	responseBody, response, err := s.do(url, "GET", nil)
+	if err != nil {
+		log.Error().Int("page", page).Int("pageSize", pageSize).Err(err).Msgf("Failed to get SLO configurations from Instana. Full response %+v", string(responseBody))
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		log.Error().Int("code", response.StatusCode).Int("page", page).Int("pageSize", pageSize).Err(err).Msgf("Unexpected response %+v", string(responseBody))
+		return nil, errors.New("unexpected response code")
+	}
+
+	var result types.SloConfigResponse
+	if responseBody != nil {
+		err = json.Unmarshal(responseBody, &result)
+		if err != nil {
+			log.Error().Int("page", page).Int("pageSize", pageSize).Err(err).Str("body", string(responseBody)).Msgf("Failed to parse body")
+			return nil, err
+		}
+		return &result, err
+	} else {
+		log.Error().Int("page", page).Int("pageSize", pageSize).Err(err).Msgf("Empty response body")
+		return nil, errors.New("empty response body")
+	}
+}
+
+func (s *Specification) GetSloReport(_ context.Context, sloId string, from time.Time, to time.Time) (*types.SloReport, error) {
+	url := fmt.Sprintf("%s/api/slo/reports/%s?from=%d&to=%d", s.BaseUrl, sloId, from.UnixMilli(), to.UnixMilli())
+
+	responseBody, response, err := s.do(url, "GET", nil)
+	if err != nil {
+		log.Error().Str("slo", sloId).Err(err).Msgf("Failed to get SLO report from Instana. Full response %+v", string(responseBody))
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		log.Error().Int("code", response.StatusCode).Str("slo", sloId).Err(err).Msgf("Unexpected response %+v", string(responseBody))
+		return nil, errors.New("unexpected response code")
+	}
+
+	var result types.SloReport
+	if responseBody != nil {
+		err = json.Unmarshal(responseBody, &result)
+		if err != nil {
+			log.Error().Str("slo", sloId).Err(err).Str("body", string(responseBody)).Msgf("Failed to parse body")
+			return nil, err
+		}
+		return &result, nil
+	} else {
+		log.Error().Str("slo", sloId).Err(err).Msgf("Empty response body")
+		return nil, errors.New("empty response body")
+	}
+}
+
+func (s *Specification) GetSyntheticTests(_ context.Context) ([]types.SyntheticTest, error) {
+	url := fmt.Sprintf("%s/api/synthetics/settings/tests", s.BaseUrl)
+
+	responseBody, response, err := s.do(url, "GET", nil)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to get synthetic tests from Instana. Full response %+v", string(responseBody))
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		log.Error().Int("code", response.StatusCode).Err(err).Msgf("Unexpected response %+v", string(responseBody))
+		return nil, errors.New("unexpected response code")
+	}
+
+	var result []types.SyntheticTest
+	if responseBody != nil {
+		err = json.Unmarshal(responseBody, &result)
+		if err != nil {
+			log.Error().Err(err).Str("body", string(responseBody)).Msgf("Failed to parse body")
+			return nil, err
+		}
+		return result, nil
+	} else {
+		log.Error().Err(err).Msgf("Empty response body")
+		return nil, errors.New("empty response body")
+	}
+}
+
+func (s *Specification) TriggerSyntheticTests(_ context.Context, runs []types.SyntheticTestRunRequest) ([]types.SyntheticTestRun, error) {
+	b, err := json.Marshal(runs)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to marshal request")
+		return nil, err
+	}
+
+	responseBody, response, err := s.do(fmt.Sprintf("%s/api/synthetics/settings/tests/ci-cd", s.BaseUrl), "POST", b)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to trigger synthetic tests in Instana. Full response %+v", string(responseBody))
+		return nil, err
+	}
+
+	if response.StatusCode != 200 && response.StatusCode != 201 {
+		log.Error().Int("code", response.StatusCode).Err(err).Msgf("Unexpected response %+v", string(responseBody))
+		return nil, errors.New("unexpected response code")
+	}
+
+	var result []types.SyntheticTestRun
+	if len(responseBody) > 0 {
+		err = json.Unmarshal(responseBody, &result)
+		if err != nil {
+			log.Error().Err(err).Str("body", string(responseBody)).Msgf("Failed to parse body")
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (s *Specification) GetSyntheticResults(_ context.Context, request types.SyntheticResultsRequest) (*types.SyntheticResultsResponse, error) {
+	b, err := json.Marshal(request)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to marshal request")
+		return nil, err
+	}
+
+	responseBody, response, err := s.do(fmt.Sprintf("%s/api/synthetics/results", s.BaseUrl), "POST", b)
+	if err != nil {
+		log.Error().Strs("testIds", request.TestIds).Err(err).Msgf("Failed to get synthetic test results from Instana. Full response %+v", string(responseBody))
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		log.Error().Int("code", response.StatusCode).Strs("testIds", request.TestIds).Err(err).Msgf("Unexpected response %+v", string(responseBody))
+		return nil, errors.New("unexpected response code")
+	}
+
+	var result types.SyntheticResultsResponse
+	if responseBody != nil {
+		err = json.Unmarshal(responseBody, &result)
+		if err != nil {
+			log.Error().Err(err).Str("body", string(responseBody)).Msgf("Failed to parse body")
+			return nil, err
+		}
+		return &result, nil
+	} else {
+		log.Error().Err(err).Msgf("Empty response body")
+		return nil, errors.New("empty response body")
+	}
+}
+
+// ValidateQuery checks that Instana accepts the Dynamic Focus Query by running a snapshot search limited to one result.
+func (s *Specification) ValidateQuery(_ context.Context, query string) error {
+	requestUrl := fmt.Sprintf("%s/api/infrastructure-monitoring/snapshots?query=%s&size=1", s.BaseUrl, url.QueryEscape(query))
+
+	responseBody, response, err := s.do(requestUrl, "GET", nil)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to validate query with Instana. Full response %+v", string(responseBody))
+		return err
+	}
+
+	if response.StatusCode != 200 {
+		log.Debug().Int("code", response.StatusCode).Str("query", query).Msgf("Query rejected by Instana %+v", string(responseBody))
+		return fmt.Errorf("query rejected by Instana with status %d: %s", response.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+	return nil
+}
+
 func (s *Specification) CreateMaintenanceWindow(_ context.Context, maintenanceWindow types.CreateMaintenanceWindowRequest) (*string, *http.Response, error) {
 	b, err := json.Marshal(maintenanceWindow)
 	if err != nil {
@@ -163,11 +683,173 @@ func (s *Specification) CreateMaintenanceWindow(_ context.Context, maintenanceWi
 	return &result.Id, response, err
 }
 
+func (s *Specification) GetMaintenanceWindows(_ context.Context) ([]types.MaintenanceWindow, error) {
+	requestUrl := fmt.Sprintf("%s/api/settings/v2/maintenance", s.BaseUrl)
+
+	responseBody, response, err := s.do(requestUrl, "GET", nil)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to get maintenance windows from Instana. Full response %+v", string(responseBody))
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		log.Error().Int("code", response.StatusCode).Err(err).Msgf("Unexpected response %+v", string(responseBody))
+		return nil, errors.New("unexpected response code")
+	}
+
+	var result []types.MaintenanceWindow
+	if responseBody != nil {
+		err = json.Unmarshal(responseBody, &result)
+		if err != nil {
+			log.Error().Err(err).Str("body", string(responseBody)).Msgf("Failed to parse body")
+			return nil, err
+		}
+		return result, nil
+	} else {
+		log.Error().Err(err).Msgf("Empty response body")
+		return nil, errors.New("empty response body")
+	}
+}
+
+func (s *Specification) GetMaintenanceWindow(_ context.Context, maintenanceWindowId string) (*types.MaintenanceWindow, error) {
+	requestUrl := fmt.Sprintf("%s/api/settings/v2/maintenance/%s", s.BaseUrl, url.PathEscape(maintenanceWindowId))
+
+	responseBody, response, err := s.do(requestUrl, "GET", nil)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to get maintenance window from Instana. Full response %+v", string(responseBody))
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		log.Error().Int("code", response.StatusCode).Err(err).Msgf("Unexpected response %+v", string(responseBody))
+		return nil, errors.New("unexpected response code")
+	}
+
+	var result types.MaintenanceWindow
+	if responseBody != nil {
+		err = json.Unmarshal(responseBody, &result)
+		if err != nil {
+			log.Error().Err(err).Str("body", string(responseBody)).Msgf("Failed to parse body")
+			return nil, err
+		}
+		return &result, nil
+	} else {
+		log.Error().Err(err).Msgf("Empty response body")
+		return nil, errors.New("empty response body")
+	}
+}
+
 func (s *Specification) DeleteMaintenanceWindow(_ context.Context, maintenanceWindowId string) (*http.Response, error) {
 	_, response, err := s.do(fmt.Sprintf("%s/api/settings/v2/maintenance/%s", s.BaseUrl, url.PathEscape(maintenanceWindowId)), "DELETE", nil)
 	return response, err
 }
 
+// alertConfigPaths maps the Smart Alert configuration types to their Instana API paths.
+var alertConfigPaths = map[string]string{
+	"application": "/api/events/settings/application-alert-configs",
+	"website":     "/api/events/settings/website-alert-configs",
+	"global":      "/api/events/settings/global-alert-configs/applications",
+}
+
+func (s *Specification) alertConfigUrl(alertConfigType string) (string, error) {
+	path, ok := alertConfigPaths[alertConfigType]
+	if !ok {
+		return "", fmt.Errorf("unknown alert configuration type '%s'", alertConfigType)
+	}
+	return s.BaseUrl + path, nil
+}
+
+func (s *Specification) GetAlertConfigs(_ context.Context, alertConfigType string) ([]types.AlertConfig, error) {
+	requestUrl, err := s.alertConfigUrl(alertConfigType)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, response, err := s.do(requestUrl, "GET", nil)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to get %s alert configurations from Instana. Full response %+v", alertConfigType, string(responseBody))
+		return nil, err
+	}
+
+	if response.StatusCode != 200 {
+		log.Error().Int("code", response.StatusCode).Err(err).Msgf("Unexpected response %+v", string(responseBody))
+		return nil, errors.New("unexpected response code")
+	}
+
+	var result []types.AlertConfig
+	if responseBody != nil {
+		err = json.Unmarshal(responseBody, &result)
+		if err != nil {
+			log.Error().Err(err).Str("body", string(responseBody)).Msgf("Failed to parse body")
+			return nil, err
+		}
+		return result, nil
+	} else {
+		log.Error().Err(err).Msgf("Empty response body")
+		return nil, errors.New("empty response body")
+	}
+}
+
+func (s *Specification) GetAlertConfig(_ context.Context, alertConfigType string, alertConfigId string) (*types.AlertConfig, error) {
+	baseUrl, err := s.alertConfigUrl(alertConfigType)
+	if err != nil {
+		return nil, err
+	}
+	requestUrl := fmt.Sprintf("%s/%s", baseUrl, url.PathEscape(alertConfigId))
+
+	responseBody, response, err := s.do(requestUrl, "GET", nil)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to get %s alert configuration from Instana. Full response %+v", alertConfigType, string(responseBody))
+		return nil, err
+	}
+
+	if response.StatusCode == 404 {
+		return nil, fmt.Errorf("%s alert configuration '%s' not found", alertConfigType, alertConfigId)
+	}
+	if response.StatusCode != 200 {
+		log.Error().Int("code", response.StatusCode).Err(err).Msgf("Unexpected response %+v", string(responseBody))
+		return nil, errors.New("unexpected response code")
+	}
+
+	var result types.AlertConfig
+	if responseBody != nil {
+		err = json.Unmarshal(responseBody, &result)
+		if err != nil {
+			log.Error().Err(err).Str("body", string(responseBody)).Msgf("Failed to parse body")
+			return nil, err
+		}
+		return &result, nil
+	} else {
+		log.Error().Err(err).Msgf("Empty response body")
+		return nil, errors.New("empty response body")
+	}
+}
+
+// SetAlertConfigEnabled enables or disables a Smart Alert configuration without changing anything else of it.
+func (s *Specification) SetAlertConfigEnabled(_ context.Context, alertConfigType string, alertConfigId string, enabled bool) error {
+	baseUrl, err := s.alertConfigUrl(alertConfigType)
+	if err != nil {
+		return err
+	}
+	operation := "disable"
+	if enabled {
+		operation = "enable"
+	}
+	requestUrl := fmt.Sprintf("%s/%s/%s", baseUrl, url.PathEscape(alertConfigId), operation)
+
+	responseBody, response, err := s.do(requestUrl, "PUT", nil)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to %s %s alert configuration in Instana. Full response %+v", operation, alertConfigType, string(responseBody))
+		return err
+	}
+
+	if response.StatusCode != 200 && response.StatusCode != 204 {
+		log.Error().Int("code", response.StatusCode).Err(err).Msgf("Unexpected response %+v", string(responseBody))
+		return errors.New("unexpected response code")
+	}
+	return nil
+}
+
 func (s *Specification) do(url string, method string, body []byte) ([]byte, *http.Response, error) {
 	var bodyReader io.Reader
 	if body != nil {
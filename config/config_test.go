@@ -68,3 +68,37 @@ func TestCreateMaintenanceWindow_EscapesIdInPath(t *testing.T) {
 	// The '/' must be percent-encoded so the id stays a single path segment.
 	assert.Equal(t, "/api/settings/v2/maintenance/exp%2F..%2F..%2Fevil", gotEscapedPath)
 }
+
+func TestGetApplicationPerspectives_AddsEscapedNameFilter(t *testing.T) {
+	var gotQuery url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query()
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"items":[]}`))
+	}))
+	defer srv.Close()
+
+	spec := Specification{BaseUrl: srv.URL, ApiToken: "X", DiscoveryApplicationsNameFilter: "shop&pageSize=1"}
+	_, err := spec.GetApplicationPerspectives(context.Background(), 1, 50)
+	require.NoError(t, err)
+
+	assert.Equal(t, "shop&pageSize=1", gotQuery.Get("nameFilter"))
+	assert.Equal(t, "50", gotQuery.Get("pageSize"))
+}
+
+func TestValidateQuery_ReportsRejectedQuery(t *testing.T) {
+	var gotQuery url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query()
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"errors":["Unknown tag entity.foo"]}`))
+	}))
+	defer srv.Close()
+
+	spec := Specification{BaseUrl: srv.URL, ApiToken: "X"}
+	err := spec.ValidateQuery(context.Background(), `entity.foo:"bar"`)
+
+	require.ErrorContains(t, err, "Unknown tag entity.foo")
+	assert.Equal(t, `entity.foo:"bar"`, gotQuery.Get("query"))
+	assert.Equal(t, "1", gotQuery.Get("size"))
+}
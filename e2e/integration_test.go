@@ -10,6 +10,7 @@ import (
 	"github.com/steadybit/discovery-kit/go/discovery_kit_test/validate"
 	"github.com/steadybit/extension-instana/extevents"
 	"github.com/steadybit/extension-instana/extmaintenance"
+	"github.com/steadybit/extension-instana/extmetrics"
 	"github.com/steadybit/extension-kit/extlogging"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -50,6 +51,10 @@ func TestWithMinikube(t *testing.T) {
 			Name: "create maintenance window",
 			Test: testCreateMaintenanceWindow,
 		},
+		{
+			Name: "application metrics check",
+			Test: testApplicationMetricsCheck,
+		},
 	})
 }
 
@@ -127,3 +132,42 @@ func testCreateMaintenanceWindow(t *testing.T, m *e2e.Minikube, e *e2e.Extension
 	require.Contains(t, Requests, "PUT-/api/settings/v2/maintenance/TST-1-47")
 	require.Contains(t, Requests, "DELETE-/api/settings/v2/maintenance/TST-1-47")
 }
+
+func testApplicationMetricsCheck(t *testing.T, m *e2e.Minikube, e *e2e.Extension) {
+	defer func() { Requests = []string{} }()
+
+	target := &action_kit_api.Target{
+		Name: "Application Perspective 1",
+		Attributes: map[string][]string{
+			"instana.application.id":    {"application-id-1"},
+			"instana.application.label": {"application-name-1"},
+		},
+	}
+
+	config := struct {
+		Duration           int    `json:"duration"`
+		LatencyAggregation string `json:"latencyAggregation"`
+		MaxLatency         int    `json:"maxLatency"`
+		MaxErrorRate       int    `json:"maxErrorRate"`
+		ConditionCheckMode string `json:"conditionCheckMode"`
+		Granularity        int    `json:"granularity"`
+	}{Duration: 1000, LatencyAggregation: "P99", MaxLatency: 800, MaxErrorRate: 1, ConditionCheckMode: "allTheTime", Granularity: 60}
+
+	executionContext := &action_kit_api.ExecutionContext{}
+
+	action, err := e.RunAction(extmetrics.ApplicationMetricsCheckActionId, target, config, executionContext)
+	defer func() { _ = action.Cancel() }()
+	require.NoError(t, err)
+	err = action.Wait()
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return len(action.Metrics()) > 0
+	}, 5*time.Second, 500*time.Millisecond)
+
+	for _, metric := range action.Metrics() {
+		assert.Equal(t, "application-name-1", metric.Metric["application"])
+		assert.Equal(t, "false", metric.Metric["violated"])
+	}
+	require.Contains(t, Requests, "POST-/api/application-monitoring/metrics/applications")
+}
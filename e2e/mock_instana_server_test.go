@@ -27,6 +27,9 @@ func createMockInstanaServer() *httptest.Server {
 			} else if strings.HasPrefix(r.URL.Path, "/api/infrastructure-monitoring/snapshots") && r.Method == http.MethodGet {
 				w.WriteHeader(http.StatusOK)
 				_, _ = w.Write(snapshots())
+			} else if strings.HasPrefix(r.URL.Path, "/api/application-monitoring/metrics/applications") && r.Method == http.MethodPost {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write(applicationMetrics())
 			} else if strings.HasPrefix(r.URL.Path, "/api/settings/v2/maintenance") && r.Method == http.MethodPut {
 				w.WriteHeader(http.StatusOK)
 				_, _ = w.Write(maintenanceWindowCreated())
@@ -80,6 +83,28 @@ func events() []byte {
   ]`)
 }
 
+func applicationMetrics() []byte {
+	return []byte(`{
+    "items": [
+        {
+            "application": {
+                "id": "application-id-1",
+                "label": "application-name-1",
+                "boundaryScope": "ALL"
+            },
+            "metrics": {
+                "calls.sum": [[1703284764000, 1200.0]],
+                "erroneousCalls.sum": [[1703284764000, 6.0]],
+                "latency.p99": [[1703284764000, 420.0]]
+            }
+        }
+    ],
+    "page": 1,
+    "pageSize": 20,
+    "totalHits": 1
+}`)
+}
+
 func maintenanceWindowCreated() []byte {
 	return []byte(`{
         "id": "TST-1-47",
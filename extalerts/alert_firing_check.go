@@ -0,0 +1,304 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extalerts
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-instana/config"
+	"github.com/steadybit/extension-instana/types"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"slices"
+	"strings"
+	"time"
+)
+
+type AlertFiringCheckAction struct{}
+
+// Make sure action implements all required interfaces
+var (
+	_ action_kit_sdk.Action[AlertFiringCheckState]           = (*AlertFiringCheckAction)(nil)
+	_ action_kit_sdk.ActionWithStatus[AlertFiringCheckState] = (*AlertFiringCheckAction)(nil)
+)
+
+type AlertFiringCheckState struct {
+	Start     time.Time
+	End       time.Time
+	Condition string
+	// AlertConfigs maps the ids of the watched Smart Alert configurations to their names
+	AlertConfigs map[string]string
+	// FiredEventIds holds the ids of the alert events raised by the watched configurations during the step
+	FiredEventIds []string
+}
+
+func NewAlertFiringCheckAction() action_kit_sdk.Action[AlertFiringCheckState] {
+	return &AlertFiringCheckAction{}
+}
+
+func (m *AlertFiringCheckAction) NewEmptyState() AlertFiringCheckState {
+	return AlertFiringCheckState{}
+}
+
+func (m *AlertFiringCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          AlertFiringCheckActionId,
+		Label:       "Smart Alert Check",
+		Description: "Checks whether Instana Smart Alerts fire during the step.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        new(alertsIcon),
+		Technology:  new("Instana"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  new(""),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: new("30s"),
+				Order:        new(1),
+				Required:     new(true),
+			},
+			{
+				Name:        "condition",
+				Label:       "Condition",
+				Description: new("Whether none of the Smart Alerts must fire during the step, or at least one of them must fire to prove the fault is detected."),
+				Type:        action_kit_api.ActionParameterTypeString,
+				Options: new([]action_kit_api.ParameterOption{
+					action_kit_api.ExplicitParameterOption{
+						Label: "Must not fire",
+						Value: conditionMustNotFire,
+					},
+					action_kit_api.ExplicitParameterOption{
+						Label: "Must fire",
+						Value: conditionMustFire,
+					},
+				}),
+				DefaultValue: new(conditionMustNotFire),
+				Order:        new(2),
+				Required:     new(true),
+			},
+			{
+				Name:        "alertConfigs",
+				Label:       "Smart Alerts",
+				Description: new("Ids or names of application, website or global Smart Alert configurations to watch."),
+				Type:        action_kit_api.ActionParameterTypeStringArray,
+				Order:       new(3),
+				Required:    new(false),
+			},
+			{
+				Name:        "applicationPerspective",
+				Label:       "Application Perspective",
+				Description: new("Id of an application perspective. All Smart Alert configurations of the application perspective are watched."),
+				Type:        action_kit_api.ActionParameterTypeString,
+				Order:       new(4),
+				Required:    new(false),
+			},
+		},
+		Widgets: new([]action_kit_api.Widget{
+			action_kit_api.StateOverTimeWidget{
+				Type:  action_kit_api.ComSteadybitWidgetStateOverTime,
+				Title: "Instana Smart Alerts",
+				Identity: action_kit_api.StateOverTimeWidgetIdentityConfig{
+					From: "id",
+				},
+				Label: action_kit_api.StateOverTimeWidgetLabelConfig{
+					From: "title",
+				},
+				State: action_kit_api.StateOverTimeWidgetStateConfig{
+					From: "state",
+				},
+				Tooltip: action_kit_api.StateOverTimeWidgetTooltipConfig{
+					From: "tooltip",
+				},
+				Url: new(action_kit_api.StateOverTimeWidgetUrlConfig{
+					From: new("url"),
+				}),
+				Value: new(action_kit_api.StateOverTimeWidgetValueConfig{
+					Hide: new(true),
+				}),
+			},
+		}),
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: new(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: new("10s"),
+		}),
+	}
+}
+
+type AlertFiringCheckApi interface {
+	GetAlertConfigs(ctx context.Context, alertConfigType string) ([]types.AlertConfig, error)
+	GetEvents(ctx context.Context, from time.Time, to time.Time, eventTypeFilters []string) ([]types.Event, error)
+}
+
+func (m *AlertFiringCheckAction) Prepare(ctx context.Context, state *AlertFiringCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	return nil, PrepareAlertFiringCheck(ctx, state, request, &config.Config)
+}
+
+// PrepareAlertFiringCheck resolves the Smart Alert configurations to watch, selected by id, name or application perspective.
+func PrepareAlertFiringCheck(ctx context.Context, state *AlertFiringCheckState, request action_kit_api.PrepareActionRequestBody, api AlertFiringCheckApi) error {
+	condition := extutil.ToString(request.Config["condition"])
+	if condition != conditionMustNotFire && condition != conditionMustFire {
+		return extension_kit.ToError(fmt.Sprintf("Unknown condition: '%s'.", condition), nil)
+	}
+	selectors := toIds(request.Config["alertConfigs"])
+	applicationPerspective := strings.TrimSpace(extutil.ToString(request.Config["applicationPerspective"]))
+	if len(selectors) == 0 && applicationPerspective == "" {
+		return extension_kit.ToError("Select Smart Alerts or an application perspective.", nil)
+	}
+
+	alertConfigs := make([]types.AlertConfig, 0)
+	applicationAlertConfigs := make([]types.AlertConfig, 0)
+	for _, alertConfigType := range []string{alertConfigTypeApplication, alertConfigTypeWebsite, alertConfigTypeGlobal} {
+		configs, err := api.GetAlertConfigs(ctx, alertConfigType)
+		if err != nil {
+			return extension_kit.ToError(fmt.Sprintf("Failed to get %s alert configurations from Instana.", alertConfigType), err)
+		}
+		alertConfigs = append(alertConfigs, configs...)
+		if alertConfigType == alertConfigTypeApplication {
+			applicationAlertConfigs = configs
+		}
+	}
+
+	state.AlertConfigs = make(map[string]string)
+	for _, selector := range selectors {
+		index := slices.IndexFunc(alertConfigs, func(alertConfig types.AlertConfig) bool {
+			return alertConfig.Id == selector || alertConfig.Name == selector
+		})
+		if index < 0 {
+			return extension_kit.ToError(fmt.Sprintf("Smart Alert configuration '%s' not found in Instana.", selector), nil)
+		}
+		state.AlertConfigs[alertConfigs[index].Id] = alertConfigs[index].Name
+	}
+	if applicationPerspective != "" {
+		found := false
+		for _, alertConfig := range applicationAlertConfigs {
+			if alertConfig.ApplicationId == applicationPerspective {
+				state.AlertConfigs[alertConfig.Id] = alertConfig.Name
+				found = true
+			}
+		}
+		if !found {
+			return extension_kit.ToError(fmt.Sprintf("No Smart Alert configuration found for application perspective '%s'.", applicationPerspective), nil)
+		}
+	}
+	log.Debug().Int("count", len(state.AlertConfigs)).Msg("Watching Smart Alert configurations.")
+
+	duration := extutil.ToInt64(request.Config["duration"])
+	state.Start = time.Now()
+	state.End = time.Now().Add(time.Millisecond * time.Duration(duration))
+	state.Condition = condition
+	state.FiredEventIds = make([]string, 0)
+	return nil
+}
+
+func (m *AlertFiringCheckAction) Start(ctx context.Context, state *AlertFiringCheckState) (*action_kit_api.StartResult, error) {
+	statusResult, err := AlertFiringCheckStatus(ctx, state, &config.Config)
+	if statusResult == nil {
+		return nil, err
+	}
+	startResult := action_kit_api.StartResult{
+		Artifacts: statusResult.Artifacts,
+		Error:     statusResult.Error,
+		Messages:  statusResult.Messages,
+		Metrics:   statusResult.Metrics,
+	}
+	return &startResult, err
+}
+
+func (m *AlertFiringCheckAction) Status(ctx context.Context, state *AlertFiringCheckState) (*action_kit_api.StatusResult, error) {
+	return AlertFiringCheckStatus(ctx, state, &config.Config)
+}
+
+// AlertFiringCheckStatus looks for alert events raised by the watched Smart Alert configurations since the start of the step.
+// A "must not fire" check fails as soon as one fires, a "must fire" check fails at the end if none has fired.
+func AlertFiringCheckStatus(ctx context.Context, state *AlertFiringCheckState, api AlertFiringCheckApi) (*action_kit_api.StatusResult, error) {
+	now := time.Now()
+	events, err := api.GetEvents(ctx, state.Start, now, nil)
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to get events from Instana.", err)
+	}
+
+	alertEvents := make([]types.Event, 0)
+	for _, event := range events {
+		if event.Start < state.Start.UnixMilli() || alertConfigName(state, event) == "" {
+			continue
+		}
+		alertEvents = append(alertEvents, event)
+		if !slices.Contains(state.FiredEventIds, event.EventId) {
+			state.FiredEventIds = append(state.FiredEventIds, event.EventId)
+		}
+	}
+
+	completed := now.After(state.End)
+	result := action_kit_api.StatusResult{
+		Completed: completed,
+		Metrics:   alertEventsToMetrics(state, alertEvents, now),
+	}
+	if state.Condition == conditionMustNotFire && len(state.FiredEventIds) > 0 {
+		names := make([]string, 0)
+		for _, event := range alertEvents {
+			name := fmt.Sprintf("'%s'", alertConfigName(state, event))
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+		result.Completed = true
+		result.Error = new(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("Smart Alerts must not fire, but %d alert events were raised by %s.", len(state.FiredEventIds), strings.Join(names, ", ")),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	}
+	if state.Condition == conditionMustFire && completed && len(state.FiredEventIds) == 0 {
+		result.Error = new(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("At least one of %d Smart Alerts must fire, but none fired.", len(state.AlertConfigs)),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	}
+	return &result, nil
+}
+
+// alertConfigName returns the name of the watched Smart Alert configuration which raised the event, or an empty string.
+// Events without an event specification id are matched by their problem, which Instana sets to the name of the Smart Alert.
+func alertConfigName(state *AlertFiringCheckState, event types.Event) string {
+	if event.EventSpecificationId != "" {
+		return state.AlertConfigs[event.EventSpecificationId]
+	}
+	for _, name := range state.AlertConfigs {
+		if name != "" && name == event.Problem {
+			return name
+		}
+	}
+	return ""
+}
+
+func alertEventsToMetrics(state *AlertFiringCheckState, events []types.Event, now time.Time) *action_kit_api.Metrics {
+	metrics := make([]action_kit_api.Metric, 0, len(events))
+	for _, event := range events {
+		tooltip := fmt.Sprintf("Smart Alert: %s\nEvent Problem: %s\nEvent Detail: %s\nEvent State: %s\nEntity Label: %s", alertConfigName(state, event), event.Problem, event.Detail, event.State, event.EntityLabel)
+		eventState := "danger"
+		if event.State == "closed" {
+			eventState = "warn"
+		}
+		metrics = append(metrics, action_kit_api.Metric{
+			Name: new("instana_smart_alerts"),
+			Metric: map[string]string{
+				"id":      event.EventId,
+				"title":   alertConfigName(state, event),
+				"state":   eventState,
+				"tooltip": tooltip,
+				"url":     fmt.Sprintf("%s/#/events;eventId=%s", config.Config.BaseUrl, event.EventId),
+			},
+			Timestamp: now,
+			Value:     0,
+		})
+	}
+	return new(metrics)
+}
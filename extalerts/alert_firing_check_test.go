@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extalerts
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func (m *instanaApiMock) GetEvents(ctx context.Context, from time.Time, to time.Time, eventTypeFilters []string) ([]types.Event, error) {
+	args := m.Called(ctx, from, to, eventTypeFilters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]types.Event), args.Error(1)
+}
+
+func mockAlertConfigs(mockedApi *instanaApiMock) {
+	mockedApi.On("GetAlertConfigs", mock.Anything, alertConfigTypeApplication).Return([]types.AlertConfig{
+		{Id: "a1", Name: "Shop latency", ApplicationId: "shop"},
+		{Id: "a2", Name: "Shop errors", ApplicationId: "shop"},
+		{Id: "a3", Name: "Checkout errors", ApplicationId: "checkout"},
+	}, nil)
+	mockedApi.On("GetAlertConfigs", mock.Anything, alertConfigTypeWebsite).Return([]types.AlertConfig{{Id: "w1", Name: "Shop JS errors"}}, nil)
+	mockedApi.On("GetAlertConfigs", mock.Anything, alertConfigTypeGlobal).Return([]types.AlertConfig{}, nil)
+}
+
+func TestPrepareAlertFiringCheckResolvesByNameIdAndPerspective(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockAlertConfigs(mockedApi)
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]any{
+			"duration":               60000,
+			"condition":              conditionMustFire,
+			"alertConfigs":           []any{"Shop JS errors"},
+			"applicationPerspective": "shop",
+		},
+	}
+	state := AlertFiringCheckState{}
+
+	// When
+	err := PrepareAlertFiringCheck(context.Background(), &state, request, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"w1": "Shop JS errors", "a1": "Shop latency", "a2": "Shop errors"}, state.AlertConfigs)
+	require.Equal(t, conditionMustFire, state.Condition)
+	require.Equal(t, time.Minute, state.End.Sub(state.Start).Round(time.Second))
+}
+
+func TestPrepareAlertFiringCheckFailsForUnknownAlertConfig(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockAlertConfigs(mockedApi)
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]any{
+			"condition":    conditionMustNotFire,
+			"alertConfigs": []any{"unknown"},
+		},
+	}
+
+	// When
+	err := PrepareAlertFiringCheck(context.Background(), &AlertFiringCheckState{}, request, mockedApi)
+
+	// Then
+	require.ErrorContains(t, err, "Smart Alert configuration 'unknown' not found in Instana.")
+}
+
+func TestMustNotFireFailsWhenWatchedAlertFires(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	start := time.Now().Add(-time.Minute)
+	mockedApi.On("GetEvents", mock.Anything, start, mock.Anything, mock.Anything).Return([]types.Event{
+		{EventId: "e1", Start: start.Add(-time.Minute).UnixMilli(), EventSpecificationId: "a1"},
+		{EventId: "e2", Start: start.Add(10 * time.Second).UnixMilli(), EventSpecificationId: "a3"},
+		{EventId: "e3", Start: start.Add(20 * time.Second).UnixMilli(), EventSpecificationId: "a1"},
+		{EventId: "e4", Start: start.Add(30 * time.Second).UnixMilli(), Problem: "Shop errors"},
+	}, nil)
+	state := AlertFiringCheckState{
+		Start:         start,
+		End:           start.Add(time.Hour),
+		Condition:     conditionMustNotFire,
+		AlertConfigs:  map[string]string{"a1": "Shop latency", "a2": "Shop errors"},
+		FiredEventIds: []string{},
+	}
+
+	// When
+	result, err := AlertFiringCheckStatus(context.Background(), &state, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.True(t, result.Completed)
+	require.Equal(t, []string{"e3", "e4"}, state.FiredEventIds)
+	require.Equal(t, "Smart Alerts must not fire, but 2 alert events were raised by 'Shop latency', 'Shop errors'.", result.Error.Title)
+	require.Len(t, *result.Metrics, 2)
+}
+
+func TestMustFireFailsAtTheEndIfNoAlertFired(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]types.Event{}, nil)
+	state := AlertFiringCheckState{
+		Start:        time.Now().Add(-time.Minute),
+		End:          time.Now().Add(-time.Second),
+		Condition:    conditionMustFire,
+		AlertConfigs: map[string]string{"a1": "Shop latency"},
+	}
+
+	// When
+	result, err := AlertFiringCheckStatus(context.Background(), &state, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.True(t, result.Completed)
+	require.Equal(t, "At least one of 1 Smart Alerts must fire, but none fired.", result.Error.Title)
+}
+
+func TestMustFireSucceedsOnceAlertFired(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	start := time.Now().Add(-time.Minute)
+	mockedApi.On("GetEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]types.Event{}, nil).Once()
+	state := AlertFiringCheckState{
+		Start:         start,
+		End:           time.Now().Add(-time.Second),
+		Condition:     conditionMustFire,
+		AlertConfigs:  map[string]string{"a1": "Shop latency"},
+		FiredEventIds: []string{"e1"},
+	}
+
+	// When
+	result, err := AlertFiringCheckStatus(context.Background(), &state, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+}
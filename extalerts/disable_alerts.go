@@ -0,0 +1,258 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extalerts
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-instana/config"
+	"github.com/steadybit/extension-instana/types"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"slices"
+	"strings"
+)
+
+type DisableAlertsAction struct{}
+
+// Make sure action implements all required interfaces
+var (
+	_ action_kit_sdk.Action[DisableAlertsState]         = (*DisableAlertsAction)(nil)
+	_ action_kit_sdk.ActionWithStop[DisableAlertsState] = (*DisableAlertsAction)(nil)
+)
+
+// DisableAlertsState holds the alert configurations to mute together with their enabled state before the step.
+// It is kept by the agent, so Stop can restore the alert configurations even after a restart of the extension.
+type DisableAlertsState struct {
+	AlertConfigs []AlertConfigState
+}
+
+type AlertConfigState struct {
+	Type string
+	Id   string
+	Name string
+	// WasEnabled is the enabled state of the alert configuration before the step
+	WasEnabled bool
+	// Disabled is set once the extension disabled the alert configuration
+	Disabled bool
+}
+
+func NewDisableAlertsAction() action_kit_sdk.Action[DisableAlertsState] {
+	return &DisableAlertsAction{}
+}
+
+func (m *DisableAlertsAction) NewEmptyState() DisableAlertsState {
+	return DisableAlertsState{}
+}
+
+func (m *DisableAlertsAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          DisableAlertsActionId,
+		Label:       "Disable Smart Alerts",
+		Description: "Disables selected Instana Smart Alert configurations for the duration of the step and restores their previous state afterwards.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        new(alertsIcon),
+		Technology:  new("Instana"),
+		Kind:        action_kit_api.Other,
+		TimeControl: action_kit_api.TimeControlExternal,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  new(""),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: new("30s"),
+				Order:        new(1),
+				Required:     new(true),
+			},
+			{
+				Name:        "applicationAlertConfigIds",
+				Label:       "Application Smart Alerts",
+				Description: new("Ids of application Smart Alert configurations to disable."),
+				Type:        action_kit_api.ActionParameterTypeStringArray,
+				Order:       new(2),
+				Required:    new(false),
+			},
+			{
+				Name:        "websiteAlertConfigIds",
+				Label:       "Website Smart Alerts",
+				Description: new("Ids of website Smart Alert configurations to disable."),
+				Type:        action_kit_api.ActionParameterTypeStringArray,
+				Order:       new(3),
+				Required:    new(false),
+			},
+			{
+				Name:        "globalAlertConfigIds",
+				Label:       "Global Smart Alerts",
+				Description: new("Ids of global application Smart Alert configurations to disable."),
+				Type:        action_kit_api.ActionParameterTypeStringArray,
+				Order:       new(4),
+				Required:    new(false),
+			},
+			{
+				Name:        "alertChannelIds",
+				Label:       "Alert Channels",
+				Description: new("Ids of alerting channels, like a PagerDuty integration. All application, website and global Smart Alert configurations notifying one of these channels are disabled."),
+				Type:        action_kit_api.ActionParameterTypeStringArray,
+				Order:       new(5),
+				Required:    new(false),
+			},
+		},
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Stop:    new(action_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+type AlertConfigApi interface {
+	GetAlertConfigs(ctx context.Context, alertConfigType string) ([]types.AlertConfig, error)
+	GetAlertConfig(ctx context.Context, alertConfigType string, alertConfigId string) (*types.AlertConfig, error)
+	SetAlertConfigEnabled(ctx context.Context, alertConfigType string, alertConfigId string, enabled bool) error
+}
+
+func (m *DisableAlertsAction) Prepare(ctx context.Context, state *DisableAlertsState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	return nil, PrepareDisableAlerts(ctx, state, request, &config.Config)
+}
+
+// PrepareDisableAlerts resolves the selected alert configurations and records whether they are currently enabled.
+func PrepareDisableAlerts(ctx context.Context, state *DisableAlertsState, request action_kit_api.PrepareActionRequestBody, api AlertConfigApi) error {
+	state.AlertConfigs = make([]AlertConfigState, 0)
+	add := func(alertConfigType string, alertConfig types.AlertConfig) {
+		if slices.ContainsFunc(state.AlertConfigs, func(existing AlertConfigState) bool {
+			return existing.Type == alertConfigType && existing.Id == alertConfig.Id
+		}) {
+			return
+		}
+		state.AlertConfigs = append(state.AlertConfigs, AlertConfigState{
+			Type:       alertConfigType,
+			Id:         alertConfig.Id,
+			Name:       alertConfig.Name,
+			WasEnabled: alertConfig.Enabled,
+		})
+	}
+
+	for _, parameter := range []struct {
+		name            string
+		alertConfigType string
+	}{
+		{"applicationAlertConfigIds", alertConfigTypeApplication},
+		{"websiteAlertConfigIds", alertConfigTypeWebsite},
+		{"globalAlertConfigIds", alertConfigTypeGlobal},
+	} {
+		for _, id := range toIds(request.Config[parameter.name]) {
+			alertConfig, err := api.GetAlertConfig(ctx, parameter.alertConfigType, id)
+			if err != nil {
+				return extension_kit.ToError(fmt.Sprintf("Failed to get %s alert configuration '%s' from Instana.", parameter.alertConfigType, id), err)
+			}
+			add(parameter.alertConfigType, *alertConfig)
+		}
+	}
+
+	channelIds := toIds(request.Config["alertChannelIds"])
+	if len(channelIds) > 0 {
+		for _, alertConfigType := range []string{alertConfigTypeApplication, alertConfigTypeWebsite, alertConfigTypeGlobal} {
+			alertConfigs, err := api.GetAlertConfigs(ctx, alertConfigType)
+			if err != nil {
+				return extension_kit.ToError(fmt.Sprintf("Failed to get %s alert configurations from Instana.", alertConfigType), err)
+			}
+			for _, alertConfig := range alertConfigs {
+				if notifiesAnyChannel(alertConfig, channelIds) {
+					add(alertConfigType, alertConfig)
+				}
+			}
+		}
+	}
+
+	if len(state.AlertConfigs) == 0 {
+		return extension_kit.ToError("No Smart Alert configuration selected.", nil)
+	}
+	return nil
+}
+
+func (m *DisableAlertsAction) Start(ctx context.Context, state *DisableAlertsState) (*action_kit_api.StartResult, error) {
+	return StartDisableAlerts(ctx, state, &config.Config)
+}
+
+// StartDisableAlerts disables all enabled alert configurations. If one fails, the already disabled ones are enabled again.
+func StartDisableAlerts(ctx context.Context, state *DisableAlertsState, api AlertConfigApi) (*action_kit_api.StartResult, error) {
+	messages := make([]action_kit_api.Message, 0)
+	for i := range state.AlertConfigs {
+		alertConfig := &state.AlertConfigs[i]
+		if !alertConfig.WasEnabled {
+			messages = append(messages, action_kit_api.Message{
+				Level:   extutil.Ptr(action_kit_api.Info),
+				Message: fmt.Sprintf("The %s alert configuration '%s' is already disabled and is left as is.", alertConfig.Type, alertConfig.Name),
+			})
+			continue
+		}
+		if err := api.SetAlertConfigEnabled(ctx, alertConfig.Type, alertConfig.Id, false); err != nil {
+			restoreAlertConfigs(ctx, state, api)
+			return nil, extension_kit.ToError(fmt.Sprintf("Failed to disable %s alert configuration '%s' in Instana.", alertConfig.Type, alertConfig.Name), err)
+		}
+		alertConfig.Disabled = true
+		log.Info().Str("type", alertConfig.Type).Str("id", alertConfig.Id).Msg("Disabled alert configuration.")
+	}
+	return &action_kit_api.StartResult{
+		Messages: new(messages),
+	}, nil
+}
+
+func (m *DisableAlertsAction) Stop(ctx context.Context, state *DisableAlertsState) (*action_kit_api.StopResult, error) {
+	return StopDisableAlerts(ctx, state, &config.Config)
+}
+
+// StopDisableAlerts enables the alert configurations disabled by the action again.
+func StopDisableAlerts(ctx context.Context, state *DisableAlertsState, api AlertConfigApi) (*action_kit_api.StopResult, error) {
+	if failed := restoreAlertConfigs(ctx, state, api); len(failed) > 0 {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to enable the alert configurations %s in Instana again.", strings.Join(failed, ", ")), nil)
+	}
+	return nil, nil
+}
+
+// restoreAlertConfigs enables the alert configurations disabled by the action and returns the names of those it failed to enable.
+func restoreAlertConfigs(ctx context.Context, state *DisableAlertsState, api AlertConfigApi) []string {
+	failed := make([]string, 0)
+	for i := range state.AlertConfigs {
+		alertConfig := &state.AlertConfigs[i]
+		if !alertConfig.Disabled {
+			continue
+		}
+		if err := api.SetAlertConfigEnabled(ctx, alertConfig.Type, alertConfig.Id, true); err != nil {
+			log.Error().Err(err).Str("type", alertConfig.Type).Str("id", alertConfig.Id).Msg("Failed to enable alert configuration again.")
+			failed = append(failed, fmt.Sprintf("'%s'", alertConfig.Name))
+			continue
+		}
+		alertConfig.Disabled = false
+		log.Info().Str("type", alertConfig.Type).Str("id", alertConfig.Id).Msg("Enabled alert configuration again.")
+	}
+	return failed
+}
+
+func notifiesAnyChannel(alertConfig types.AlertConfig, channelIds []string) bool {
+	for _, channelId := range channelIds {
+		if slices.Contains(alertConfig.AlertChannelIds, channelId) {
+			return true
+		}
+		for _, severityChannelIds := range alertConfig.AlertChannels {
+			if slices.Contains(severityChannelIds, channelId) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func toIds(value any) []string {
+	ids := make([]string, 0)
+	for _, id := range extutil.ToStringArray(value) {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
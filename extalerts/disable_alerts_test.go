@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extalerts
+
+import (
+	"context"
+	"errors"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type instanaApiMock struct {
+	mock.Mock
+}
+
+func (m *instanaApiMock) GetAlertConfigs(ctx context.Context, alertConfigType string) ([]types.AlertConfig, error) {
+	args := m.Called(ctx, alertConfigType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]types.AlertConfig), args.Error(1)
+}
+
+func (m *instanaApiMock) GetAlertConfig(ctx context.Context, alertConfigType string, alertConfigId string) (*types.AlertConfig, error) {
+	args := m.Called(ctx, alertConfigType, alertConfigId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.AlertConfig), args.Error(1)
+}
+
+func (m *instanaApiMock) SetAlertConfigEnabled(ctx context.Context, alertConfigType string, alertConfigId string, enabled bool) error {
+	args := m.Called(ctx, alertConfigType, alertConfigId, enabled)
+	return args.Error(0)
+}
+
+func TestPrepareResolvesAlertConfigsByIdAndChannel(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetAlertConfig", mock.Anything, alertConfigTypeApplication, "a1").Return(&types.AlertConfig{Id: "a1", Name: "Shop latency", Enabled: true}, nil)
+	mockedApi.On("GetAlertConfig", mock.Anything, alertConfigTypeWebsite, "w1").Return(&types.AlertConfig{Id: "w1", Name: "Shop JS errors", Enabled: false}, nil)
+	mockedApi.On("GetAlertConfigs", mock.Anything, alertConfigTypeApplication).Return([]types.AlertConfig{
+		{Id: "a1", Name: "Shop latency", Enabled: true, AlertChannelIds: []string{"pagerduty"}},
+		{Id: "a2", Name: "Cart errors", Enabled: true, AlertChannels: map[string][]string{"CRITICAL": {"pagerduty"}}},
+		{Id: "a3", Name: "Other", Enabled: true, AlertChannelIds: []string{"slack"}},
+	}, nil)
+	mockedApi.On("GetAlertConfigs", mock.Anything, alertConfigTypeWebsite).Return([]types.AlertConfig{}, nil)
+	mockedApi.On("GetAlertConfigs", mock.Anything, alertConfigTypeGlobal).Return([]types.AlertConfig{
+		{Id: "g1", Name: "Global errors", Enabled: true, AlertChannelIds: []string{"pagerduty"}},
+	}, nil)
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]any{
+			"applicationAlertConfigIds": []any{"a1", " "},
+			"websiteAlertConfigIds":     []any{"w1"},
+			"alertChannelIds":           []any{"pagerduty"},
+		},
+	}
+	state := DisableAlertsState{}
+
+	// When
+	err := PrepareDisableAlerts(context.Background(), &state, request, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, []AlertConfigState{
+		{Type: alertConfigTypeApplication, Id: "a1", Name: "Shop latency", WasEnabled: true},
+		{Type: alertConfigTypeWebsite, Id: "w1", Name: "Shop JS errors", WasEnabled: false},
+		{Type: alertConfigTypeApplication, Id: "a2", Name: "Cart errors", WasEnabled: true},
+		{Type: alertConfigTypeGlobal, Id: "g1", Name: "Global errors", WasEnabled: true},
+	}, state.AlertConfigs)
+}
+
+func TestPrepareFailsWithoutAlertConfigs(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	state := DisableAlertsState{}
+
+	// When
+	err := PrepareDisableAlerts(context.Background(), &state, action_kit_api.PrepareActionRequestBody{Config: map[string]any{}}, mockedApi)
+
+	// Then
+	require.ErrorContains(t, err, "No Smart Alert configuration selected.")
+}
+
+func TestStartDisablesOnlyEnabledAlertConfigsAndStopRestoresThem(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("SetAlertConfigEnabled", mock.Anything, alertConfigTypeApplication, "a1", false).Return(nil).Once()
+	mockedApi.On("SetAlertConfigEnabled", mock.Anything, alertConfigTypeApplication, "a1", true).Return(nil).Once()
+	state := DisableAlertsState{AlertConfigs: []AlertConfigState{
+		{Type: alertConfigTypeApplication, Id: "a1", Name: "Shop latency", WasEnabled: true},
+		{Type: alertConfigTypeWebsite, Id: "w1", Name: "Shop JS errors", WasEnabled: false},
+	}}
+
+	// When
+	startResult, startErr := StartDisableAlerts(context.Background(), &state, mockedApi)
+	disabled := state.AlertConfigs[0].Disabled
+	_, stopErr := StopDisableAlerts(context.Background(), &state, mockedApi)
+
+	// Then
+	require.NoError(t, startErr)
+	require.Len(t, *startResult.Messages, 1)
+	require.True(t, disabled)
+	require.NoError(t, stopErr)
+	require.False(t, state.AlertConfigs[0].Disabled)
+	mockedApi.AssertExpectations(t)
+	mockedApi.AssertNotCalled(t, "SetAlertConfigEnabled", mock.Anything, alertConfigTypeWebsite, "w1", mock.Anything)
+}
+
+func TestStartRestoresAlertConfigsIfDisablingFails(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("SetAlertConfigEnabled", mock.Anything, alertConfigTypeApplication, "a1", false).Return(nil)
+	mockedApi.On("SetAlertConfigEnabled", mock.Anything, alertConfigTypeGlobal, "g1", false).Return(errors.New("oops"))
+	mockedApi.On("SetAlertConfigEnabled", mock.Anything, alertConfigTypeApplication, "a1", true).Return(nil)
+	state := DisableAlertsState{AlertConfigs: []AlertConfigState{
+		{Type: alertConfigTypeApplication, Id: "a1", Name: "Shop latency", WasEnabled: true},
+		{Type: alertConfigTypeGlobal, Id: "g1", Name: "Global errors", WasEnabled: true},
+	}}
+
+	// When
+	_, err := StartDisableAlerts(context.Background(), &state, mockedApi)
+
+	// Then
+	require.ErrorContains(t, err, "Failed to disable global alert configuration 'Global errors'")
+	require.False(t, state.AlertConfigs[0].Disabled)
+	require.False(t, state.AlertConfigs[1].Disabled)
+	mockedApi.AssertExpectations(t)
+}
+
+func TestStopReportsAlertConfigsFailingToBeEnabled(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("SetAlertConfigEnabled", mock.Anything, alertConfigTypeApplication, "a1", true).Return(errors.New("oops"))
+	state := DisableAlertsState{AlertConfigs: []AlertConfigState{
+		{Type: alertConfigTypeApplication, Id: "a1", Name: "Shop latency", WasEnabled: true, Disabled: true},
+	}}
+
+	// When
+	_, err := StopDisableAlerts(context.Background(), &state, mockedApi)
+
+	// Then
+	require.ErrorContains(t, err, "'Shop latency'")
+	require.True(t, state.AlertConfigs[0].Disabled)
+}
@@ -5,16 +5,31 @@ package extapplications
 
 import (
 	"context"
+	"fmt"
 	"github.com/rs/zerolog/log"
 	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
 	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
 	"github.com/steadybit/extension-instana/config"
+	"github.com/steadybit/extension-instana/extcommon"
 	"github.com/steadybit/extension-instana/types"
 	"github.com/steadybit/extension-kit/extbuild"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 )
 
+const (
+	defaultPageSize       = 100
+	maxConcurrentRequests = 4
+)
+
 type applicationPerspectiveDiscovery struct {
+	api GetApplicationPerspectivesApi
+	// applicationServices is shared with the service discovery, which needs the services of the perspectives, too.
+	applicationServices *extcommon.ApplicationServicesCache
+	// lastKnownGood is the result of the last complete discovery, served again while Instana requests fail.
+	lastKnownGood extcommon.LastKnownGoodTargets
 }
 
 var (
@@ -23,17 +38,17 @@ var (
 )
 
 func NewApplicationPerspectiveDiscovery() discovery_kit_sdk.TargetDiscovery {
-	discovery := &applicationPerspectiveDiscovery{}
+	discovery := &applicationPerspectiveDiscovery{api: &config.Config, applicationServices: extcommon.SharedApplicationServices}
 	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
 		discovery_kit_sdk.WithRefreshTargetsNow(),
-		discovery_kit_sdk.WithRefreshTargetsInterval(context.Background(), 1*time.Minute),
+		discovery_kit_sdk.WithRefreshTargetsInterval(context.Background(), config.Config.DiscoveryApplicationsRefreshInterval),
 	)
 }
 func (d *applicationPerspectiveDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
 	return discovery_kit_api.DiscoveryDescription{
 		Id: ApplicationPerspectiveTargetId,
 		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
-			CallInterval: new("1m"),
+			CallInterval: new(config.Config.DiscoveryApplicationsCallInterval),
 		},
 	}
 }
@@ -62,55 +77,248 @@ func (d *applicationPerspectiveDiscovery) DescribeTarget() discovery_kit_api.Tar
 func (d *applicationPerspectiveDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDescription {
 	return []discovery_kit_api.AttributeDescription{
 		{
-			Attribute: "instana.application-perspective.name",
+			Attribute: "instana.application.id",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana application perspective id",
+				Other: "Instana application perspective ids",
+			},
+		},
+		{
+			Attribute: "instana.application.label",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana application perspective label",
+				Other: "Instana application perspective labels",
+			},
+		},
+		{
+			Attribute: "instana.application.scope",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana application perspective scope",
+				Other: "Instana application perspective scopes",
+			},
+		},
+		{
+			Attribute: "instana.application.boundary-scope",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana application perspective boundary scope",
+				Other: "Instana application perspective boundary scopes",
+			},
+		},
+		{
+			Attribute: "instana.application.tag-filter",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana application perspective tag filter",
+				Other: "Instana application perspective tag filters",
+			},
+		},
+		{
+			Attribute: "instana.application.service",
 			Label: discovery_kit_api.PluralLabel{
-				One:   "Instana application perspective name",
-				Other: "Instana application perspective names",
+				One:   "Instana application perspective service",
+				Other: "Instana application perspective services",
+			},
+		},
+		{
+			Attribute: "instana.application.technology",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana application perspective technology",
+				Other: "Instana application perspective technologies",
+			},
+		},
+		{
+			Attribute: "instana.application.stale-since",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana application perspective stale since",
+				Other: "Instana application perspectives stale since",
 			},
 		},
 	}
 }
 
 func (d *applicationPerspectiveDiscovery) DiscoverTargets(ctx context.Context) ([]discovery_kit_api.Target, error) {
-	return getAllApplicationPerspectives(ctx, &config.Config), nil
+	return d.discover(ctx, d.api)
+}
+
+// discoveryPageSize returns the configured page size for fetching application perspectives and their services.
+func discoveryPageSize() int {
+	if config.Config.DiscoveryApplicationsPageSize > 0 {
+		return config.Config.DiscoveryApplicationsPageSize
+	}
+	return defaultPageSize
+}
+
+// discover returns the current application perspectives or, if the discovery fails, the last complete result marked
+// with the instana.application.stale-since attribute.
+func (d *applicationPerspectiveDiscovery) discover(ctx context.Context, api GetApplicationPerspectivesApi) ([]discovery_kit_api.Target, error) {
+	targets, err := getAllApplicationPerspectives(ctx, api, d.applicationServices, config.Config.DiscoveryApplicationsTagFilter)
+	return d.lastKnownGood.Resolve(targets, err, "instana.application.stale-since", "application perspectives")
 }
 
 type GetApplicationPerspectivesApi interface {
 	GetApplicationPerspectives(ctx context.Context, page int, pageSize int) (*types.ApplicationPerspectiveResponse, error)
+	GetApplicationConfigs(ctx context.Context) ([]types.ApplicationConfig, error)
+	GetApplicationServices(ctx context.Context, applicationPerspectiveId string, page int, pageSize int) (*types.ServiceResponse, error)
 }
 
-func getAllApplicationPerspectives(ctx context.Context, api GetApplicationPerspectivesApi) []discovery_kit_api.Target {
-	result := make([]discovery_kit_api.Target, 0, 500)
+// getAllApplicationPerspectives fetches all pages of application perspectives, using the total hits of the first
+// page to fetch the remaining pages concurrently. Any failed page, including the services of a perspective, fails the
+// whole discovery to not publish a truncated list.
+// If tags are given, only perspectives whose tag filter expression contains all of them are returned. The Instana API
+// has no tag filter for application perspectives, so they are filtered after fetching them and their configurations.
+func getAllApplicationPerspectives(ctx context.Context, api GetApplicationPerspectivesApi, applicationServices *extcommon.ApplicationServicesCache, tags []string) ([]discovery_kit_api.Target, error) {
+	start := time.Now()
+	pageSize := discoveryPageSize()
+	log.Debug().Int("page", 1).Msg("Fetch application perspectives from Instana")
+	first, err := api.GetApplicationPerspectives(ctx, 1, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application perspectives from Instana for page 1 and page size %d: %w", pageSize, err)
+	}
 
-	pageSize := 100
-	page := 1
+	pages := [][]types.ApplicationPerspective{first.Items}
+	if _, hasMore := first.Links["next"]; hasMore && len(first.Items) > 0 {
+		var remaining [][]types.ApplicationPerspective
+		if first.TotalHits > 0 {
+			remaining, err = fetchPagesConcurrently(ctx, api, (first.TotalHits+pageSize-1)/pageSize)
+		} else {
+			remaining, err = fetchPagesSequentially(ctx, api)
+		}
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, remaining...)
+	}
 
-	start := time.Now()
-	for {
+	perspectives := slices.Concat(pages...)
+	configs, err := getApplicationConfigs(ctx, api)
+	if err != nil {
+		if len(tags) > 0 {
+			return nil, fmt.Errorf("failed to get application perspective configurations required for the tag filter: %w", err)
+		}
+		log.Err(err).Msg("Failed to get application perspective configurations from Instana.")
+	}
+	if len(tags) > 0 {
+		perspectives = slices.DeleteFunc(perspectives, func(perspective types.ApplicationPerspective) bool {
+			return !matchesTags(configs[perspective.Id], tags)
+		})
+	}
+	result := make([]discovery_kit_api.Target, len(perspectives))
+	err = forEachConcurrently(len(perspectives), func(i int) error {
+		services, err := applicationServices.Get(ctx, api, perspectives[i].Id, pageSize)
+		if err != nil {
+			return err
+		}
+		result[i] = toTarget(perspectives[i], configs[perspectives[i].Id], services)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Debug().Msgf("Discovery took %s, returning %d application perspectives.", time.Since(start), len(result))
+	return result, nil
+}
+
+// fetchPagesConcurrently fetches the pages 2 to lastPage with bounded parallelism, keeping the page order.
+func fetchPagesConcurrently(ctx context.Context, api GetApplicationPerspectivesApi, lastPage int) ([][]types.ApplicationPerspective, error) {
+	pageSize := discoveryPageSize()
+	if lastPage < 2 {
+		return nil, nil
+	}
+	pages := make([][]types.ApplicationPerspective, lastPage-1)
+	err := forEachConcurrently(len(pages), func(i int) error {
+		page := i + 2
 		log.Debug().Int("page", page).Msg("Fetch application perspectives from Instana")
 		response, err := api.GetApplicationPerspectives(ctx, page, pageSize)
 		if err != nil {
-			log.Err(err).Msgf("Failed to get application perspectives from Instana for page %d and page size %d.", page, pageSize)
-			return result
+			return fmt.Errorf("failed to get application perspectives from Instana for page %d and page size %d: %w", page, pageSize, err)
 		}
+		pages[i] = response.Items
+		return nil
+	})
+	return pages, err
+}
 
-		perspectives := response.Items
-		for _, perspective := range perspectives {
-			result = append(result, toTarget(perspective))
+// fetchPagesSequentially follows the next links from page 2 on, used if Instana doesn't report the total hits.
+func fetchPagesSequentially(ctx context.Context, api GetApplicationPerspectivesApi) ([][]types.ApplicationPerspective, error) {
+	pageSize := discoveryPageSize()
+	pages := make([][]types.ApplicationPerspective, 0)
+	for page := 2; ; page++ {
+		log.Debug().Int("page", page).Msg("Fetch application perspectives from Instana")
+		response, err := api.GetApplicationPerspectives(ctx, page, pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get application perspectives from Instana for page %d and page size %d: %w", page, pageSize, err)
 		}
+		pages = append(pages, response.Items)
 
 		_, hasMore := response.Links["next"]
-		if len(perspectives) == 0 || !hasMore {
+		if len(response.Items) == 0 || !hasMore {
 			// end of list reached
-			break
+			return pages, nil
 		}
-		page = page + 1
 	}
-	log.Debug().Msgf("Discovery took %s, returning %d application perspectives.", time.Since(start), len(result))
+}
+
+// forEachConcurrently calls fn for every index with at most maxConcurrentRequests calls in flight and returns the first error.
+func forEachConcurrently(n int, fn func(i int) error) error {
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	semaphore := make(chan struct{}, maxConcurrentRequests)
+	for i := range n {
+		semaphore <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-semaphore }()
+			if err := fn(i); err != nil {
+				once.Do(func() { firstErr = err })
+			}
+		})
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// getApplicationConfigs returns the application perspective configurations keyed by id.
+func getApplicationConfigs(ctx context.Context, api GetApplicationPerspectivesApi) (map[string]types.ApplicationConfig, error) {
+	result := make(map[string]types.ApplicationConfig)
+	configs, err := api.GetApplicationConfigs(ctx)
+	if err != nil {
+		return result, err
+	}
+	for _, applicationConfig := range configs {
+		result[applicationConfig.Id] = applicationConfig
+	}
+	return result, nil
+}
+
+// matchesTags checks that every tag, either 'name' or 'name=value', is used by the tag filter expression of the configuration.
+func matchesTags(applicationConfig types.ApplicationConfig, tags []string) bool {
+	if applicationConfig.TagFilterExpression == nil {
+		return false
+	}
+	filters := tagFilters(*applicationConfig.TagFilterExpression)
+	for _, tag := range tags {
+		name, value, hasValue := strings.Cut(strings.TrimSpace(tag), "=")
+		if !slices.ContainsFunc(filters, func(filter types.TagFilter) bool {
+			return filter.Name == name && (!hasValue || fmt.Sprintf("%v", filter.Value) == value)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// tagFilters returns the leaf tag filters of an expression.
+func tagFilters(filter types.TagFilter) []types.TagFilter {
+	if filter.Type != "EXPRESSION" {
+		return []types.TagFilter{filter}
+	}
+	result := make([]types.TagFilter, 0, len(filter.Elements))
+	for _, element := range filter.Elements {
+		result = append(result, tagFilters(element)...)
+	}
 	return result
 }
 
-func toTarget(perspective types.ApplicationPerspective) discovery_kit_api.Target {
+func toTarget(perspective types.ApplicationPerspective, applicationConfig types.ApplicationConfig, services []types.Service) discovery_kit_api.Target {
 	id := perspective.Id
 	label := perspective.Label
 
@@ -118,6 +326,29 @@ func toTarget(perspective types.ApplicationPerspective) discovery_kit_api.Target
 	attributes["steadybit.label"] = []string{label}
 	attributes["instana.application.label"] = []string{label}
 	attributes["instana.application.id"] = []string{id}
+	if applicationConfig.Scope != "" {
+		attributes["instana.application.scope"] = []string{applicationConfig.Scope}
+	}
+	if applicationConfig.BoundaryScope != "" {
+		attributes["instana.application.boundary-scope"] = []string{applicationConfig.BoundaryScope}
+	}
+	if applicationConfig.TagFilterExpression != nil {
+		if expression := formatTagFilter(*applicationConfig.TagFilterExpression); expression != "" {
+			attributes["instana.application.tag-filter"] = []string{expression}
+		}
+	}
+	technologies := make([]string, 0)
+	for _, service := range services {
+		attributes["instana.application.service"] = append(attributes["instana.application.service"], service.Label)
+		for _, technology := range service.Technologies {
+			if !slices.Contains(technologies, technology) {
+				technologies = append(technologies, technology)
+			}
+		}
+	}
+	if len(technologies) > 0 {
+		attributes["instana.application.technology"] = technologies
+	}
 
 	return discovery_kit_api.Target{
 		Id:         id,
@@ -126,3 +357,31 @@ func toTarget(perspective types.ApplicationPerspective) discovery_kit_api.Target
 		Attributes: attributes,
 	}
 }
+
+// formatTagFilter renders a tag filter expression in a human-readable form, e.g. `kubernetes.namespace.name EQUALS "shop" AND service.name CONTAINS "cart"`.
+func formatTagFilter(filter types.TagFilter) string {
+	if filter.Type == "EXPRESSION" {
+		parts := make([]string, 0, len(filter.Elements))
+		for _, element := range filter.Elements {
+			part := formatTagFilter(element)
+			if part == "" {
+				continue
+			}
+			if element.Type == "EXPRESSION" && len(element.Elements) > 1 {
+				part = "(" + part + ")"
+			}
+			parts = append(parts, part)
+		}
+		return strings.Join(parts, " "+filter.LogicalOperator+" ")
+	}
+	if filter.Name == "" {
+		return ""
+	}
+	if filter.Value == nil {
+		return fmt.Sprintf("%s %s", filter.Name, filter.Operator)
+	}
+	if value, ok := filter.Value.(string); ok {
+		return fmt.Sprintf("%s %s %q", filter.Name, filter.Operator, value)
+	}
+	return fmt.Sprintf("%s %s %v", filter.Name, filter.Operator, filter.Value)
+}
@@ -6,10 +6,14 @@ package extapplications
 import (
 	"context"
 	"errors"
+	"fmt"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-instana/extcommon"
 	"github.com/steadybit/extension-instana/types"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 type instanaApiMock struct {
@@ -24,6 +28,22 @@ func (m *instanaApiMock) GetApplicationPerspectives(ctx context.Context, page in
 	return args.Get(0).(*types.ApplicationPerspectiveResponse), args.Error(1)
 }
 
+func (m *instanaApiMock) GetApplicationConfigs(ctx context.Context) ([]types.ApplicationConfig, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]types.ApplicationConfig), args.Error(1)
+}
+
+func (m *instanaApiMock) GetApplicationServices(ctx context.Context, applicationPerspectiveId string, page int, pageSize int) (*types.ServiceResponse, error) {
+	args := m.Called(ctx, applicationPerspectiveId, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.ServiceResponse), args.Error(1)
+}
+
 func TestIterateThroughMonitorsResponses(t *testing.T) {
 	// Given
 	mockedApi := new(instanaApiMock)
@@ -50,11 +70,14 @@ func TestIterateThroughMonitorsResponses(t *testing.T) {
 
 	mockedApi.On("GetApplicationPerspectives", mock.Anything, 1, mock.Anything).Return(&page1, nil)
 	mockedApi.On("GetApplicationPerspectives", mock.Anything, 2, mock.Anything).Return(&page2, nil)
+	mockedApi.On("GetApplicationConfigs", mock.Anything).Return([]types.ApplicationConfig{}, nil)
+	mockedApi.On("GetApplicationServices", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&types.ServiceResponse{}, nil)
 
 	// When
-	monitors := getAllApplicationPerspectives(context.Background(), mockedApi)
+	monitors, err := getAllApplicationPerspectives(context.Background(), mockedApi, extcommon.NewApplicationServicesCache(time.Minute), nil)
 
 	// Then
+	require.NoError(t, err)
 	require.Len(t, monitors, 2)
 	require.Equal(t, "id1", monitors[0].Id)
 	require.Equal(t, "name1", monitors[0].Label)
@@ -63,7 +86,7 @@ func TestIterateThroughMonitorsResponses(t *testing.T) {
 	mockedApi.AssertNumberOfCalls(t, "GetApplicationPerspectives", 2)
 }
 
-func TestErrorResponseReturnsIntermediateResult(t *testing.T) {
+func TestErrorResponseFailsInsteadOfReturningIntermediateResult(t *testing.T) {
 	// Given
 	mockedApi := new(instanaApiMock)
 	page1 := types.ApplicationPerspectiveResponse{
@@ -80,13 +103,241 @@ func TestErrorResponseReturnsIntermediateResult(t *testing.T) {
 
 	mockedApi.On("GetApplicationPerspectives", mock.Anything, 1, mock.Anything).Return(&page1, nil)
 	mockedApi.On("GetApplicationPerspectives", mock.Anything, 2, mock.Anything).Return(nil, errors.New("oops"))
+	mockedApi.On("GetApplicationConfigs", mock.Anything).Return([]types.ApplicationConfig{}, nil)
+	mockedApi.On("GetApplicationServices", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&types.ServiceResponse{}, nil)
 
 	// When
-	monitors := getAllApplicationPerspectives(context.Background(), mockedApi)
+	monitors, err := getAllApplicationPerspectives(context.Background(), mockedApi, extcommon.NewApplicationServicesCache(time.Minute), nil)
 
 	// Then
-	require.Len(t, monitors, 1)
-	require.Equal(t, "id1", monitors[0].Id)
-	require.Equal(t, "name1", monitors[0].Label)
+	require.ErrorContains(t, err, "oops")
+	require.Nil(t, monitors)
 	mockedApi.AssertNumberOfCalls(t, "GetApplicationPerspectives", 2)
 }
+
+func TestApplicationPerspectiveIsEnrichedWithConfigAndServices(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetApplicationPerspectives", mock.Anything, 1, mock.Anything).Return(&types.ApplicationPerspectiveResponse{
+		Items: []types.ApplicationPerspective{{Id: "id1", Label: "shop"}},
+	}, nil)
+	mockedApi.On("GetApplicationConfigs", mock.Anything).Return([]types.ApplicationConfig{
+		{
+			Id:            "id1",
+			Label:         "shop",
+			Scope:         "INCLUDE_NO_DOWNSTREAM",
+			BoundaryScope: "INBOUND",
+			TagFilterExpression: &types.TagFilter{
+				Type:            "EXPRESSION",
+				LogicalOperator: "AND",
+				Elements: []types.TagFilter{
+					{Type: "TAG_FILTER", Name: "kubernetes.namespace.name", Operator: "EQUALS", Value: "shop"},
+					{
+						Type:            "EXPRESSION",
+						LogicalOperator: "OR",
+						Elements: []types.TagFilter{
+							{Type: "TAG_FILTER", Name: "service.name", Operator: "CONTAINS", Value: "cart"},
+							{Type: "TAG_FILTER", Name: "call.http.status", Operator: "GREATER_OR_EQUAL", Value: 500.0},
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+	mockedApi.On("GetApplicationServices", mock.Anything, "id1", 1, mock.Anything).Return(&types.ServiceResponse{
+		Items: []types.Service{
+			{Id: "s1", Label: "cart", Technologies: []string{"java", "springbootApplicationContainer"}},
+			{Id: "s2", Label: "checkout", Technologies: []string{"java"}},
+		},
+		TotalHits: 2,
+	}, nil)
+
+	// When
+	targets, err := getAllApplicationPerspectives(context.Background(), mockedApi, extcommon.NewApplicationServicesCache(time.Minute), nil)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, targets, 1)
+	attributes := targets[0].Attributes
+	require.Equal(t, []string{"id1"}, attributes["instana.application.id"])
+	require.Equal(t, []string{"shop"}, attributes["instana.application.label"])
+	require.Equal(t, []string{"INCLUDE_NO_DOWNSTREAM"}, attributes["instana.application.scope"])
+	require.Equal(t, []string{"INBOUND"}, attributes["instana.application.boundary-scope"])
+	require.Equal(t, []string{`kubernetes.namespace.name EQUALS "shop" AND (service.name CONTAINS "cart" OR call.http.status GREATER_OR_EQUAL 500)`}, attributes["instana.application.tag-filter"])
+	require.Equal(t, []string{"cart", "checkout"}, attributes["instana.application.service"])
+	require.Equal(t, []string{"java", "springbootApplicationContainer"}, attributes["instana.application.technology"])
+}
+
+func TestApplicationPerspectiveWithoutConfigStillDiscovered(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetApplicationPerspectives", mock.Anything, 1, mock.Anything).Return(&types.ApplicationPerspectiveResponse{
+		Items: []types.ApplicationPerspective{{Id: "id1", Label: "shop"}},
+	}, nil)
+	mockedApi.On("GetApplicationConfigs", mock.Anything).Return(nil, errors.New("forbidden"))
+	mockedApi.On("GetApplicationServices", mock.Anything, "id1", 1, mock.Anything).Return(&types.ServiceResponse{}, nil)
+
+	// When
+	targets, err := getAllApplicationPerspectives(context.Background(), mockedApi, extcommon.NewApplicationServicesCache(time.Minute), nil)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, targets, 1)
+	require.Equal(t, []string{"shop"}, targets[0].Attributes["instana.application.label"])
+	require.NotContains(t, targets[0].Attributes, "instana.application.scope")
+	require.NotContains(t, targets[0].Attributes, "instana.application.service")
+}
+
+func TestServicesErrorFailsInsteadOfReturningPerspectivesWithoutServices(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetApplicationPerspectives", mock.Anything, 1, mock.Anything).Return(&types.ApplicationPerspectiveResponse{
+		Items: []types.ApplicationPerspective{{Id: "id1", Label: "shop"}},
+	}, nil)
+	mockedApi.On("GetApplicationConfigs", mock.Anything).Return([]types.ApplicationConfig{}, nil)
+	mockedApi.On("GetApplicationServices", mock.Anything, "id1", 1, mock.Anything).Return(nil, errors.New("forbidden"))
+
+	// When
+	targets, err := getAllApplicationPerspectives(context.Background(), mockedApi, extcommon.NewApplicationServicesCache(time.Minute), nil)
+
+	// Then
+	require.ErrorContains(t, err, "forbidden")
+	require.Nil(t, targets)
+}
+
+func TestPagesAreFetchedConcurrentlyUsingTotalHits(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	for page := 1; page <= 5; page++ {
+		mockedApi.On("GetApplicationPerspectives", mock.Anything, page, defaultPageSize).Return(&types.ApplicationPerspectiveResponse{
+			Items:     []types.ApplicationPerspective{{Id: fmt.Sprintf("id%d", page), Label: fmt.Sprintf("name%d", page)}},
+			Links:     map[string]string{"next": "next"},
+			TotalHits: 5 * defaultPageSize,
+		}, nil)
+	}
+	mockedApi.On("GetApplicationConfigs", mock.Anything).Return([]types.ApplicationConfig{}, nil)
+	mockedApi.On("GetApplicationServices", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&types.ServiceResponse{}, nil)
+
+	// When
+	targets, err := getAllApplicationPerspectives(context.Background(), mockedApi, extcommon.NewApplicationServicesCache(time.Minute), nil)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, targets, 5)
+	for i, target := range targets {
+		require.Equal(t, fmt.Sprintf("id%d", i+1), target.Id)
+	}
+	mockedApi.AssertNumberOfCalls(t, "GetApplicationPerspectives", 5)
+}
+
+func TestDiscoveryServesLastKnownGoodResultOnError(t *testing.T) {
+	// Given
+	discovery := &applicationPerspectiveDiscovery{applicationServices: extcommon.NewApplicationServicesCache(time.Minute)}
+	healthyApi := new(instanaApiMock)
+	healthyApi.On("GetApplicationPerspectives", mock.Anything, 1, mock.Anything).Return(&types.ApplicationPerspectiveResponse{
+		Items: []types.ApplicationPerspective{{Id: "id1", Label: "name1"}, {Id: "id2", Label: "name2"}},
+	}, nil)
+	healthyApi.On("GetApplicationConfigs", mock.Anything).Return([]types.ApplicationConfig{}, nil)
+	healthyApi.On("GetApplicationServices", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&types.ServiceResponse{}, nil)
+	failingApi := new(instanaApiMock)
+	failingApi.On("GetApplicationPerspectives", mock.Anything, 1, mock.Anything).Return(nil, errors.New("oops"))
+
+	// When
+	_, err := discovery.discover(context.Background(), healthyApi)
+	require.NoError(t, err)
+	targets, err := discovery.discover(context.Background(), failingApi)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, targets, 2)
+	require.NotNil(t, discovery.lastKnownGood.StaleSince())
+	require.Equal(t, []string{discovery.lastKnownGood.StaleSince().Format(time.RFC3339)}, targets[0].Attributes["instana.application.stale-since"])
+
+	// When
+	targets, err = discovery.discover(context.Background(), healthyApi)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, targets, 2)
+	require.Nil(t, discovery.lastKnownGood.StaleSince())
+	require.NotContains(t, targets[0].Attributes, "instana.application.stale-since")
+}
+
+func TestCachedDiscoveryPublishesLastKnownGoodResultOnError(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetApplicationPerspectives", mock.Anything, 1, mock.Anything).Return(&types.ApplicationPerspectiveResponse{
+		Items: []types.ApplicationPerspective{{Id: "id1", Label: "name1"}, {Id: "id2", Label: "name2"}},
+	}, nil).Once()
+	mockedApi.On("GetApplicationPerspectives", mock.Anything, 1, mock.Anything).Return(nil, errors.New("oops"))
+	mockedApi.On("GetApplicationConfigs", mock.Anything).Return([]types.ApplicationConfig{}, nil)
+	mockedApi.On("GetApplicationServices", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&types.ServiceResponse{}, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	discovery := discovery_kit_sdk.NewCachedTargetDiscovery(
+		&applicationPerspectiveDiscovery{api: mockedApi, applicationServices: extcommon.NewApplicationServicesCache(time.Minute)},
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsInterval(ctx, 10*time.Millisecond),
+	)
+
+	// When / Then
+	require.Eventually(t, func() bool {
+		targets, err := discovery.DiscoverTargets(ctx)
+		return err == nil && len(targets) == 2 && len(targets[0].Attributes["instana.application.stale-since"]) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestDiscoveryWithoutPreviousResultReturnsError(t *testing.T) {
+	// Given
+	discovery := &applicationPerspectiveDiscovery{applicationServices: extcommon.NewApplicationServicesCache(time.Minute)}
+	failingApi := new(instanaApiMock)
+	failingApi.On("GetApplicationPerspectives", mock.Anything, 1, mock.Anything).Return(nil, errors.New("oops"))
+
+	// When
+	targets, err := discovery.discover(context.Background(), failingApi)
+
+	// Then
+	require.ErrorContains(t, err, "oops")
+	require.Nil(t, targets)
+}
+
+func TestApplicationPerspectivesAreFilteredByTags(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetApplicationPerspectives", mock.Anything, 1, mock.Anything).Return(&types.ApplicationPerspectiveResponse{
+		Items: []types.ApplicationPerspective{{Id: "id1", Label: "shop"}, {Id: "id2", Label: "billing"}, {Id: "id3", Label: "unconfigured"}},
+	}, nil)
+	mockedApi.On("GetApplicationConfigs", mock.Anything).Return([]types.ApplicationConfig{
+		{Id: "id1", TagFilterExpression: &types.TagFilter{Type: "EXPRESSION", LogicalOperator: "AND", Elements: []types.TagFilter{
+			{Type: "TAG_FILTER", Name: "kubernetes.namespace.name", Operator: "EQUALS", Value: "shop"},
+			{Type: "TAG_FILTER", Name: "service.name", Operator: "NOT_EMPTY"},
+		}}},
+		{Id: "id2", TagFilterExpression: &types.TagFilter{Type: "TAG_FILTER", Name: "kubernetes.namespace.name", Operator: "EQUALS", Value: "billing"}},
+	}, nil)
+	mockedApi.On("GetApplicationServices", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&types.ServiceResponse{}, nil)
+
+	// When
+	targets, err := getAllApplicationPerspectives(context.Background(), mockedApi, extcommon.NewApplicationServicesCache(time.Minute), []string{"kubernetes.namespace.name=shop", "service.name"})
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, targets, 1)
+	require.Equal(t, "id1", targets[0].Id)
+	mockedApi.AssertNotCalled(t, "GetApplicationServices", mock.Anything, "id2", mock.Anything, mock.Anything)
+}
+
+func TestTagFilterFailsWithoutConfigurations(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetApplicationPerspectives", mock.Anything, 1, mock.Anything).Return(&types.ApplicationPerspectiveResponse{
+		Items: []types.ApplicationPerspective{{Id: "id1", Label: "shop"}},
+	}, nil)
+	mockedApi.On("GetApplicationConfigs", mock.Anything).Return(nil, errors.New("forbidden"))
+
+	// When
+	targets, err := getAllApplicationPerspectives(context.Background(), mockedApi, extcommon.NewApplicationServicesCache(time.Minute), []string{"kubernetes.namespace.name"})
+
+	// Then
+	require.ErrorContains(t, err, "forbidden")
+	require.Nil(t, targets)
+}
@@ -0,0 +1,243 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extapplications
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-instana/config"
+	"github.com/steadybit/extension-instana/extcommon"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/steadybit/extension-kit/extbuild"
+	"slices"
+	"time"
+)
+
+const (
+	kubernetesDeploymentPlugin = "kubernetesDeployment"
+	hostPlugin                 = "host"
+	snapshotDetailsBatchSize   = 100
+)
+
+type applicationEnrichmentDataDiscovery struct {
+	api GetApplicationEnrichmentDataApi
+	// lastKnownGood is the result of the last complete discovery, served again while Instana requests fail.
+	lastKnownGood extcommon.LastKnownGoodEnrichmentData
+}
+
+var (
+	_ discovery_kit_sdk.EnrichmentRulesDescriber = (*applicationEnrichmentDataDiscovery)(nil)
+	_ discovery_kit_sdk.AttributeDescriber       = (*applicationEnrichmentDataDiscovery)(nil)
+)
+
+func NewApplicationEnrichmentDataDiscovery() discovery_kit_sdk.EnrichmentDataDiscovery {
+	discovery := &applicationEnrichmentDataDiscovery{api: &config.Config}
+	return discovery_kit_sdk.NewCachedEnrichmentDataDiscovery(discovery,
+		discovery_kit_sdk.WithRefreshEnrichmentDataNow(),
+		discovery_kit_sdk.WithRefreshEnrichmentDataInterval(context.Background(), 5*time.Minute),
+	)
+}
+
+func (d *applicationEnrichmentDataDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id: ApplicationEnrichmentDiscoveryId,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			CallInterval: new("5m"),
+		},
+	}
+}
+
+func (d *applicationEnrichmentDataDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDescription {
+	return []discovery_kit_api.AttributeDescription{
+		{
+			Attribute: "instana.application.id",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana application perspective id",
+				Other: "Instana application perspective ids",
+			},
+		},
+		{
+			Attribute: "instana.application.label",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana application perspective label",
+				Other: "Instana application perspective labels",
+			},
+		},
+	}
+}
+
+func (d *applicationEnrichmentDataDiscovery) DescribeEnrichmentRules() []discovery_kit_api.TargetEnrichmentRule {
+	workloadSelector := func(prefix string) map[string]string {
+		return map[string]string{
+			"k8s.cluster-name": fmt.Sprintf("${%s.k8s.cluster-name}", prefix),
+			"k8s.namespace":    fmt.Sprintf("${%s.k8s.namespace}", prefix),
+			"k8s.deployment":   fmt.Sprintf("${%s.k8s.deployment}", prefix),
+		}
+	}
+	hostSelector := func(prefix string) map[string]string {
+		return map[string]string{
+			"host.hostname": fmt.Sprintf("${%s.host.hostname}", prefix),
+		}
+	}
+	rule := func(id string, enrichmentDataType string, targetType string, selector func(prefix string) map[string]string) discovery_kit_api.TargetEnrichmentRule {
+		return discovery_kit_api.TargetEnrichmentRule{
+			Id:      id,
+			Version: extbuild.GetSemverVersionStringOrUnknown(),
+			Src: discovery_kit_api.SourceOrDestination{
+				Type:     enrichmentDataType,
+				Selector: selector("dest"),
+			},
+			Dest: discovery_kit_api.SourceOrDestination{
+				Type:     targetType,
+				Selector: selector("src"),
+			},
+			Attributes: []discovery_kit_api.Attribute{
+				{Matcher: discovery_kit_api.Equals, Name: "instana.application.id"},
+				{Matcher: discovery_kit_api.Equals, Name: "instana.application.label"},
+			},
+		}
+	}
+	return []discovery_kit_api.TargetEnrichmentRule{
+		rule("com.steadybit.extension_instana.application-to-kubernetes-deployment", WorkloadEnrichmentDataType, "com.steadybit.extension_kubernetes.kubernetes-deployment", workloadSelector),
+		rule("com.steadybit.extension_instana.application-to-container", WorkloadEnrichmentDataType, "com.steadybit.extension_container.container", workloadSelector),
+		rule("com.steadybit.extension_instana.application-to-host", HostEnrichmentDataType, "com.steadybit.extension_host.host", hostSelector),
+	}
+}
+
+func (d *applicationEnrichmentDataDiscovery) DiscoverEnrichmentData(ctx context.Context) ([]discovery_kit_api.EnrichmentData, error) {
+	data, err := getApplicationEnrichmentData(ctx, d.api)
+	return d.lastKnownGood.Resolve(data, err, "application enrichment data")
+}
+
+type GetApplicationEnrichmentDataApi interface {
+	GetApplicationPerspectives(ctx context.Context, page int, pageSize int) (*types.ApplicationPerspectiveResponse, error)
+	GetApplicationSnapshots(ctx context.Context, applicationPerspectiveId string, plugin string) ([]types.Snapshot, error)
+	GetSnapshotDetails(ctx context.Context, snapshotIds []string) ([]types.SnapshotDetails, error)
+}
+
+// getApplicationEnrichmentData resolves the Kubernetes workloads and hosts of every application perspective via the
+// infrastructure snapshots and returns one enrichment data entry per workload / host listing all its perspectives.
+// Any failed request fails the whole discovery, as a partial result would remove perspectives from enriched targets.
+func getApplicationEnrichmentData(ctx context.Context, api GetApplicationEnrichmentDataApi) ([]discovery_kit_api.EnrichmentData, error) {
+	start := time.Now()
+	perspectives := make([]types.ApplicationPerspective, 0)
+	pageSize := discoveryPageSize()
+	for page := 1; ; page++ {
+		response, err := api.GetApplicationPerspectives(ctx, page, pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get application perspectives from Instana for page %d and page size %d: %w", page, pageSize, err)
+		}
+		perspectives = append(perspectives, response.Items...)
+		_, hasMore := response.Links["next"]
+		if len(response.Items) == 0 || !hasMore {
+			break
+		}
+	}
+
+	perspectivesBySnapshot := make(map[string][]types.ApplicationPerspective)
+	for _, perspective := range perspectives {
+		for _, plugin := range []string{kubernetesDeploymentPlugin, hostPlugin} {
+			snapshots, err := api.GetApplicationSnapshots(ctx, perspective.Id, plugin)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get %s snapshots of application perspective %s from Instana: %w", plugin, perspective.Id, err)
+			}
+			for _, snapshot := range snapshots {
+				perspectivesBySnapshot[snapshot.SnapshotId] = append(perspectivesBySnapshot[snapshot.SnapshotId], perspective)
+			}
+		}
+	}
+
+	snapshotIds := make([]string, 0, len(perspectivesBySnapshot))
+	for snapshotId := range perspectivesBySnapshot {
+		snapshotIds = append(snapshotIds, snapshotId)
+	}
+	slices.Sort(snapshotIds)
+
+	enrichmentData := make(map[string]*discovery_kit_api.EnrichmentData)
+	order := make([]string, 0)
+	for batch := range slices.Chunk(snapshotIds, snapshotDetailsBatchSize) {
+		details, err := api.GetSnapshotDetails(ctx, batch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get snapshot details from Instana: %w", err)
+		}
+		for _, snapshot := range details {
+			data := toEnrichmentData(snapshot)
+			if data == nil {
+				log.Debug().Str("snapshotId", snapshot.SnapshotId).Str("plugin", snapshot.Plugin).Msg("Skipping snapshot without matching metadata.")
+				continue
+			}
+			key := data.EnrichmentDataType + "/" + data.Id
+			existing, ok := enrichmentData[key]
+			if !ok {
+				existing = data
+				enrichmentData[key] = existing
+				order = append(order, key)
+			}
+			for _, perspective := range perspectivesBySnapshot[snapshot.SnapshotId] {
+				if slices.Contains(existing.Attributes["instana.application.id"], perspective.Id) {
+					continue
+				}
+				existing.Attributes["instana.application.id"] = append(existing.Attributes["instana.application.id"], perspective.Id)
+				existing.Attributes["instana.application.label"] = append(existing.Attributes["instana.application.label"], perspective.Label)
+			}
+		}
+	}
+
+	result := make([]discovery_kit_api.EnrichmentData, 0, len(order))
+	for _, key := range order {
+		result = append(result, *enrichmentData[key])
+	}
+	log.Debug().Msgf("Enrichment data discovery took %s, returning %d entries.", time.Since(start), len(result))
+	return result, nil
+}
+
+func toEnrichmentData(snapshot types.SnapshotDetails) *discovery_kit_api.EnrichmentData {
+	switch snapshot.Plugin {
+	case kubernetesDeploymentPlugin:
+		cluster := snapshotData(snapshot, "clusterName", "cluster")
+		namespace := snapshotData(snapshot, "namespace")
+		name := snapshotData(snapshot, "name")
+		if cluster == "" || namespace == "" || name == "" {
+			return nil
+		}
+		return &discovery_kit_api.EnrichmentData{
+			Id:                 fmt.Sprintf("%s/%s/%s", cluster, namespace, name),
+			EnrichmentDataType: WorkloadEnrichmentDataType,
+			Attributes: map[string][]string{
+				"k8s.cluster-name": {cluster},
+				"k8s.namespace":    {namespace},
+				"k8s.deployment":   {name},
+			},
+		}
+	case hostPlugin:
+		hostname := snapshotData(snapshot, "hostname")
+		if hostname == "" {
+			hostname = snapshot.Label
+		}
+		if hostname == "" {
+			return nil
+		}
+		return &discovery_kit_api.EnrichmentData{
+			Id:                 hostname,
+			EnrichmentDataType: HostEnrichmentDataType,
+			Attributes: map[string][]string{
+				"host.hostname": {hostname},
+			},
+		}
+	}
+	return nil
+}
+
+// snapshotData returns the first non-empty string value of the given keys from the plugin specific snapshot data.
+func snapshotData(snapshot types.SnapshotDetails, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := snapshot.Data[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extapplications
+
+import (
+	"context"
+	"errors"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func (m *instanaApiMock) GetApplicationSnapshots(ctx context.Context, applicationPerspectiveId string, plugin string) ([]types.Snapshot, error) {
+	args := m.Called(ctx, applicationPerspectiveId, plugin)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]types.Snapshot), args.Error(1)
+}
+
+func (m *instanaApiMock) GetSnapshotDetails(ctx context.Context, snapshotIds []string) ([]types.SnapshotDetails, error) {
+	args := m.Called(ctx, snapshotIds)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]types.SnapshotDetails), args.Error(1)
+}
+
+func TestEnrichmentDataListsApplicationsPerWorkloadAndHost(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetApplicationPerspectives", mock.Anything, 1, mock.Anything).Return(&types.ApplicationPerspectiveResponse{
+		Items: []types.ApplicationPerspective{{Id: "app1", Label: "shop"}, {Id: "app2", Label: "checkout"}},
+	}, nil)
+	mockedApi.On("GetApplicationSnapshots", mock.Anything, "app1", kubernetesDeploymentPlugin).Return([]types.Snapshot{{SnapshotId: "d1"}, {SnapshotId: "d2"}}, nil)
+	mockedApi.On("GetApplicationSnapshots", mock.Anything, "app1", hostPlugin).Return([]types.Snapshot{{SnapshotId: "h1"}}, nil)
+	mockedApi.On("GetApplicationSnapshots", mock.Anything, "app2", kubernetesDeploymentPlugin).Return([]types.Snapshot{{SnapshotId: "d2"}}, nil)
+	mockedApi.On("GetApplicationSnapshots", mock.Anything, "app2", hostPlugin).Return([]types.Snapshot{}, nil)
+	mockedApi.On("GetSnapshotDetails", mock.Anything, []string{"d1", "d2", "h1"}).Return([]types.SnapshotDetails{
+		{SnapshotId: "d1", Plugin: kubernetesDeploymentPlugin, Data: map[string]any{"clusterName": "prod", "namespace": "shop", "name": "cart"}},
+		{SnapshotId: "d2", Plugin: kubernetesDeploymentPlugin, Data: map[string]any{"clusterName": "prod", "namespace": "shop", "name": "checkout"}},
+		{SnapshotId: "h1", Plugin: hostPlugin, Label: "node-1", Data: map[string]any{}},
+	}, nil)
+
+	// When
+	data, err := getApplicationEnrichmentData(context.Background(), mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, data, 3)
+	require.Equal(t, "prod/shop/cart", data[0].Id)
+	require.Equal(t, WorkloadEnrichmentDataType, data[0].EnrichmentDataType)
+	require.Equal(t, []string{"cart"}, data[0].Attributes["k8s.deployment"])
+	require.Equal(t, []string{"app1"}, data[0].Attributes["instana.application.id"])
+	require.Equal(t, "prod/shop/checkout", data[1].Id)
+	require.Equal(t, []string{"app1", "app2"}, data[1].Attributes["instana.application.id"])
+	require.Equal(t, []string{"shop", "checkout"}, data[1].Attributes["instana.application.label"])
+	require.Equal(t, HostEnrichmentDataType, data[2].EnrichmentDataType)
+	require.Equal(t, []string{"node-1"}, data[2].Attributes["host.hostname"])
+	require.Equal(t, []string{"shop"}, data[2].Attributes["instana.application.label"])
+}
+
+func TestEnrichmentDataSkipsWorkloadsWithoutMetadata(t *testing.T) {
+	// Given
+	snapshot := types.SnapshotDetails{SnapshotId: "d1", Plugin: kubernetesDeploymentPlugin, Data: map[string]any{"namespace": "shop", "name": "cart"}}
+
+	// When
+	data := toEnrichmentData(snapshot)
+
+	// Then
+	require.Nil(t, data)
+}
+
+func TestFailedSnapshotsFailTheEnrichmentDataDiscovery(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetApplicationPerspectives", mock.Anything, 1, mock.Anything).Return(&types.ApplicationPerspectiveResponse{
+		Items: []types.ApplicationPerspective{{Id: "app1", Label: "shop"}},
+	}, nil)
+	mockedApi.On("GetApplicationSnapshots", mock.Anything, "app1", kubernetesDeploymentPlugin).Return([]types.Snapshot{{SnapshotId: "d1"}}, nil)
+	mockedApi.On("GetApplicationSnapshots", mock.Anything, "app1", hostPlugin).Return(nil, errors.New("oops"))
+
+	// When
+	data, err := getApplicationEnrichmentData(context.Background(), mockedApi)
+
+	// Then
+	require.ErrorContains(t, err, "oops")
+	require.Nil(t, data)
+	mockedApi.AssertNotCalled(t, "GetSnapshotDetails", mock.Anything, mock.Anything)
+}
+
+func TestEnrichmentDataDiscoveryServesLastKnownGoodDataIfSnapshotDetailsFail(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetApplicationPerspectives", mock.Anything, 1, mock.Anything).Return(&types.ApplicationPerspectiveResponse{
+		Items: []types.ApplicationPerspective{{Id: "app1", Label: "shop"}},
+	}, nil)
+	mockedApi.On("GetApplicationSnapshots", mock.Anything, "app1", kubernetesDeploymentPlugin).Return([]types.Snapshot{}, nil)
+	mockedApi.On("GetApplicationSnapshots", mock.Anything, "app1", hostPlugin).Return([]types.Snapshot{{SnapshotId: "h1"}}, nil)
+	mockedApi.On("GetSnapshotDetails", mock.Anything, []string{"h1"}).Return([]types.SnapshotDetails{
+		{SnapshotId: "h1", Plugin: hostPlugin, Label: "node-1", Data: map[string]any{}},
+	}, nil).Once()
+	mockedApi.On("GetSnapshotDetails", mock.Anything, []string{"h1"}).Return(nil, errors.New("oops"))
+	discovery := &applicationEnrichmentDataDiscovery{api: mockedApi}
+
+	// When
+	_, err := discovery.DiscoverEnrichmentData(context.Background())
+	require.NoError(t, err)
+	data, err := discovery.DiscoverEnrichmentData(context.Background())
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, data, 1)
+	require.Equal(t, []string{"node-1"}, data[0].Attributes["host.hostname"])
+	mockedApi.AssertNumberOfCalls(t, "GetSnapshotDetails", 2)
+}
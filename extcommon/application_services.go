@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcommon
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/extension-instana/types"
+	"sync"
+	"time"
+)
+
+// applicationServicesTtl is shorter than the refresh interval of the discoveries, so every refresh sees fresh services,
+// but the application perspective and the service discovery refreshing at about the same time share one fetch.
+const applicationServicesTtl = 30 * time.Second
+
+// SharedApplicationServices is the cache used by all discoveries needing the services of application perspectives.
+var SharedApplicationServices = NewApplicationServicesCache(applicationServicesTtl)
+
+type GetApplicationServicesApi interface {
+	GetApplicationServices(ctx context.Context, applicationPerspectiveId string, page int, pageSize int) (*types.ServiceResponse, error)
+}
+
+// ApplicationServicesCache caches the services of application perspectives. Concurrent requests for the same
+// application perspective wait for a single fetch. Failed fetches are not cached.
+type ApplicationServicesCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*applicationServicesEntry
+}
+
+type applicationServicesEntry struct {
+	done      chan struct{}
+	services  []types.Service
+	err       error
+	fetchedAt time.Time
+}
+
+func NewApplicationServicesCache(ttl time.Duration) *ApplicationServicesCache {
+	return &ApplicationServicesCache{
+		ttl:     ttl,
+		entries: make(map[string]*applicationServicesEntry),
+	}
+}
+
+// Get returns all services of the application perspective, fetching them from Instana if they are not cached.
+func (c *ApplicationServicesCache) Get(ctx context.Context, api GetApplicationServicesApi, applicationPerspectiveId string, pageSize int) ([]types.Service, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[applicationPerspectiveId]
+	if ok && c.isUsable(entry) {
+		c.mu.Unlock()
+	} else {
+		c.removeExpired()
+		entry = &applicationServicesEntry{done: make(chan struct{})}
+		c.entries[applicationPerspectiveId] = entry
+		c.mu.Unlock()
+
+		entry.services, entry.err = fetchApplicationServices(ctx, api, applicationPerspectiveId, pageSize)
+		entry.fetchedAt = time.Now()
+		close(entry.done)
+	}
+
+	select {
+	case <-entry.done:
+		return entry.services, entry.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// isUsable reports whether the entry is being fetched or holds services fetched within the ttl. Requires c.mu.
+func (c *ApplicationServicesCache) isUsable(entry *applicationServicesEntry) bool {
+	select {
+	case <-entry.done:
+		return entry.err == nil && time.Since(entry.fetchedAt) < c.ttl
+	default:
+		return true
+	}
+}
+
+// removeExpired drops the entries which can't be used anymore, e.g. of deleted application perspectives. Requires c.mu.
+func (c *ApplicationServicesCache) removeExpired() {
+	for id, entry := range c.entries {
+		if !c.isUsable(entry) {
+			delete(c.entries, id)
+		}
+	}
+}
+
+// fetchApplicationServices pages through the services of an application perspective. Any failed page fails the fetch.
+func fetchApplicationServices(ctx context.Context, api GetApplicationServicesApi, applicationPerspectiveId string, pageSize int) ([]types.Service, error) {
+	result := make([]types.Service, 0)
+	for page := 1; ; page++ {
+		response, err := api.GetApplicationServices(ctx, applicationPerspectiveId, page, pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get services of application perspective %s from Instana for page %d and page size %d: %w", applicationPerspectiveId, page, pageSize, err)
+		}
+		result = append(result, response.Items...)
+		if len(response.Items) == 0 || len(result) >= response.TotalHits {
+			return result, nil
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcommon
+
+import (
+	"context"
+	"errors"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type instanaApiMock struct {
+	mock.Mock
+}
+
+func (m *instanaApiMock) GetApplicationServices(ctx context.Context, applicationPerspectiveId string, page int, pageSize int) (*types.ServiceResponse, error) {
+	args := m.Called(ctx, applicationPerspectiveId, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.ServiceResponse), args.Error(1)
+}
+
+func TestApplicationServicesAreFetchedOnceWithinTtl(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetApplicationServices", mock.Anything, "app1", 1, 1).Return(&types.ServiceResponse{
+		Items:     []types.Service{{Id: "s1"}},
+		TotalHits: 2,
+	}, nil)
+	mockedApi.On("GetApplicationServices", mock.Anything, "app1", 2, 1).Return(&types.ServiceResponse{
+		Items:     []types.Service{{Id: "s2"}},
+		TotalHits: 2,
+	}, nil)
+	cache := NewApplicationServicesCache(time.Minute)
+
+	// When
+	first, firstErr := cache.Get(context.Background(), mockedApi, "app1", 1)
+	second, secondErr := cache.Get(context.Background(), mockedApi, "app1", 1)
+
+	// Then
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	require.Equal(t, []types.Service{{Id: "s1"}, {Id: "s2"}}, first)
+	require.Equal(t, first, second)
+	mockedApi.AssertNumberOfCalls(t, "GetApplicationServices", 2)
+}
+
+func TestApplicationServicesErrorsAreNotCached(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetApplicationServices", mock.Anything, "app1", 1, 100).Return(nil, errors.New("oops")).Once()
+	mockedApi.On("GetApplicationServices", mock.Anything, "app1", 1, 100).Return(&types.ServiceResponse{
+		Items:     []types.Service{{Id: "s1"}},
+		TotalHits: 1,
+	}, nil).Once()
+	cache := NewApplicationServicesCache(time.Minute)
+
+	// When
+	_, firstErr := cache.Get(context.Background(), mockedApi, "app1", 100)
+	services, secondErr := cache.Get(context.Background(), mockedApi, "app1", 100)
+
+	// Then
+	require.ErrorContains(t, firstErr, "oops")
+	require.NoError(t, secondErr)
+	require.Equal(t, []types.Service{{Id: "s1"}}, services)
+}
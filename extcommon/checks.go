@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcommon
+
+import (
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-kit/extutil"
+	"strconv"
+	"strings"
+)
+
+const (
+	ConditionCheckModeAtLeastOnce = "atLeastOnce"
+	ConditionCheckModeAllTheTime  = "allTheTime"
+)
+
+func ConditionCheckModeParameter(order int) action_kit_api.ActionParameter {
+	return action_kit_api.ActionParameter{
+		Name:         "conditionCheckMode",
+		Label:        "Condition Check Mode",
+		Description:  new("Should the step succeed if the thresholds are met at least once or all the time?"),
+		Type:         action_kit_api.ActionParameterTypeString,
+		DefaultValue: new(ConditionCheckModeAllTheTime),
+		Options: new([]action_kit_api.ParameterOption{
+			action_kit_api.ExplicitParameterOption{
+				Label: "All the time",
+				Value: ConditionCheckModeAllTheTime,
+			},
+			action_kit_api.ExplicitParameterOption{
+				Label: "At least once",
+				Value: ConditionCheckModeAtLeastOnce,
+			},
+		}),
+		Required: new(true),
+		Order:    new(order),
+	}
+}
+
+func GranularityParameter(order int) action_kit_api.ActionParameter {
+	return action_kit_api.ActionParameter{
+		Name:         "granularity",
+		Label:        "Granularity (s)",
+		Description:  new("Size of the time window in seconds the metrics are aggregated over on each poll."),
+		Type:         action_kit_api.ActionParameterTypeInteger,
+		DefaultValue: new("60"),
+		MinValue:     new(1),
+		Order:        new(order),
+		Required:     new(true),
+		Advanced:     new(true),
+	}
+}
+
+// OptionalFloat returns the numeric config value of the key, or nil if it is not set.
+func OptionalFloat(config map[string]any, key string) *float64 {
+	value, ok := config[key]
+	if !ok || value == nil || value == "" {
+		return nil
+	}
+	switch value := value.(type) {
+	case float64:
+		return new(value)
+	case string:
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return new(parsed)
+		}
+		return nil
+	}
+	return new(float64(extutil.ToInt64(value)))
+}
+
+// LatestValue returns the value of the most recent data point of the metric.
+func LatestValue(metrics map[string][][]float64, key string) (float64, bool) {
+	dataPoints := metrics[key]
+	if len(dataPoints) == 0 || len(dataPoints[len(dataPoints)-1]) < 2 {
+		return 0, false
+	}
+	return dataPoints[len(dataPoints)-1][1], true
+}
+
+// EvaluateCondition applies the condition check mode to the violations of the current poll.
+func EvaluateCondition(conditionCheckMode string, violations []string, completed bool, conditionCheckSuccess *bool) *action_kit_api.ActionKitError {
+	if conditionCheckMode == ConditionCheckModeAllTheTime && len(violations) > 0 {
+		return new(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("Thresholds violated: %s.", strings.Join(violations, ", ")),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	}
+	if conditionCheckMode == ConditionCheckModeAtLeastOnce {
+		if len(violations) == 0 {
+			*conditionCheckSuccess = true
+		}
+		if completed && !*conditionCheckSuccess {
+			return new(action_kit_api.ActionKitError{
+				Title:  "Thresholds were never met during the check.",
+				Status: extutil.Ptr(action_kit_api.Failed),
+			})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcommon
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestOptionalFloat(t *testing.T) {
+	// Given
+	config := map[string]any{
+		"fraction": 0.5,
+		"string":   "99.95",
+		"integer":  10,
+		"empty":    "",
+		"invalid":  "abc",
+	}
+
+	// When / Then
+	require.Equal(t, 0.5, *OptionalFloat(config, "fraction"))
+	require.Equal(t, 99.95, *OptionalFloat(config, "string"))
+	require.Equal(t, 10.0, *OptionalFloat(config, "integer"))
+	require.Nil(t, OptionalFloat(config, "empty"))
+	require.Nil(t, OptionalFloat(config, "invalid"))
+	require.Nil(t, OptionalFloat(config, "missing"))
+}
+
+func TestEvaluateConditionAtLeastOnce(t *testing.T) {
+	// Given
+	success := false
+
+	// When
+	violated := EvaluateCondition(ConditionCheckModeAtLeastOnce, []string{"too slow"}, false, &success)
+	met := EvaluateCondition(ConditionCheckModeAtLeastOnce, nil, false, &success)
+	completed := EvaluateCondition(ConditionCheckModeAtLeastOnce, []string{"too slow"}, true, &success)
+
+	// Then
+	require.Nil(t, violated)
+	require.Nil(t, met)
+	require.Nil(t, completed)
+	require.True(t, success)
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcommon
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"maps"
+	"sync"
+	"time"
+)
+
+// LastKnownGoodTargets keeps the result of the last complete discovery, so that targets don't disappear from the target
+// list because of a single failed request to Instana.
+type LastKnownGoodTargets struct {
+	mu      sync.Mutex
+	targets []discovery_kit_api.Target
+	// staleSince is set while the targets are served because the latest discovery failed.
+	staleSince *time.Time
+}
+
+// Resolve returns the targets of a successful discovery and remembers them. If the discovery failed, it returns the last
+// known good targets without an error, which makes sure the cached target discovery publishes them. They are marked with
+// the staleAttribute holding the time since when the discovery fails. Without a previous result the error is returned.
+func (l *LastKnownGoodTargets) Resolve(targets []discovery_kit_api.Target, err error, staleAttribute string, name string) ([]discovery_kit_api.Target, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err == nil {
+		l.targets = targets
+		l.staleSince = nil
+		return targets, nil
+	}
+	if l.targets == nil {
+		return nil, err
+	}
+	if l.staleSince == nil {
+		l.staleSince = new(time.Now())
+	}
+	log.Warn().Err(err).Msgf("Failed to discover %s, serving %d %s discovered before %s.", name, len(l.targets), name, l.staleSince.Format(time.RFC3339))
+	return markStale(l.targets, staleAttribute, *l.staleSince), nil
+}
+
+// StaleSince returns the time since when the last known good targets are served, or nil if the latest discovery succeeded.
+func (l *LastKnownGoodTargets) StaleSince() *time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.staleSince
+}
+
+// markStale returns copies of the targets with the time since when the Instana requests fail.
+func markStale(targets []discovery_kit_api.Target, staleAttribute string, staleSince time.Time) []discovery_kit_api.Target {
+	result := make([]discovery_kit_api.Target, len(targets))
+	for i, target := range targets {
+		target.Attributes = maps.Clone(target.Attributes)
+		if target.Attributes == nil {
+			target.Attributes = make(map[string][]string)
+		}
+		target.Attributes[staleAttribute] = []string{staleSince.Format(time.RFC3339)}
+		result[i] = target
+	}
+	return result
+}
+
+// LastKnownGoodEnrichmentData keeps the result of the last complete enrichment data discovery, so that enriched
+// attributes don't disappear from the targets because of a single failed request to Instana.
+type LastKnownGoodEnrichmentData struct {
+	mu   sync.Mutex
+	data []discovery_kit_api.EnrichmentData
+	// failingSince is set while the data is served because the latest discovery failed.
+	failingSince *time.Time
+}
+
+// Resolve returns the enrichment data of a successful discovery and remembers it. If the discovery failed, it returns
+// the last known good enrichment data without an error. Unlike targets, the data isn't marked, as only the attributes
+// of the enrichment rules are copied to the targets. Without a previous result the error is returned.
+func (l *LastKnownGoodEnrichmentData) Resolve(data []discovery_kit_api.EnrichmentData, err error, name string) ([]discovery_kit_api.EnrichmentData, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err == nil {
+		l.data = data
+		l.failingSince = nil
+		return data, nil
+	}
+	if l.data == nil {
+		return nil, err
+	}
+	if l.failingSince == nil {
+		l.failingSince = new(time.Now())
+	}
+	log.Warn().Err(err).Msgf("Failed to discover %s, serving %d entries discovered before %s.", name, len(l.data), l.failingSince.Format(time.RFC3339))
+	return l.data, nil
+}
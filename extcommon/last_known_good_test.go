@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcommon
+
+import (
+	"errors"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestStaleTargetsAreMarkedWithoutChangingTheLastKnownGoodTargets(t *testing.T) {
+	// Given
+	lastKnownGood := LastKnownGoodTargets{}
+	targets := []discovery_kit_api.Target{{Id: "t1", Attributes: map[string][]string{"steadybit.label": {"t1"}}}}
+	_, err := lastKnownGood.Resolve(targets, nil, "stale-since", "targets")
+	require.NoError(t, err)
+
+	// When
+	stale, err := lastKnownGood.Resolve(nil, errors.New("oops"), "stale-since", "targets")
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, lastKnownGood.StaleSince())
+	require.Len(t, stale[0].Attributes["stale-since"], 1)
+	require.Equal(t, []string{"t1"}, stale[0].Attributes["steadybit.label"])
+	require.NotContains(t, targets[0].Attributes, "stale-since")
+}
+
+func TestLastKnownGoodEnrichmentDataIsServedIfTheDiscoveryFails(t *testing.T) {
+	// Given
+	lastKnownGood := LastKnownGoodEnrichmentData{}
+	_, err := lastKnownGood.Resolve(nil, errors.New("oops"), "enrichment data")
+	require.ErrorContains(t, err, "oops")
+	data := []discovery_kit_api.EnrichmentData{{Id: "d1", Attributes: map[string][]string{"host.hostname": {"node-1"}}}}
+	_, err = lastKnownGood.Resolve(data, nil, "enrichment data")
+	require.NoError(t, err)
+
+	// When
+	stale, err := lastKnownGood.Resolve(nil, errors.New("oops"), "enrichment data")
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, data, stale)
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package exteum
+
+import (
+	"context"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-instana/config"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/steadybit/extension-kit/extbuild"
+	"time"
+)
+
+type mobileAppDiscovery struct {
+}
+
+var (
+	_ discovery_kit_sdk.TargetDescriber    = (*mobileAppDiscovery)(nil)
+	_ discovery_kit_sdk.AttributeDescriber = (*mobileAppDiscovery)(nil)
+)
+
+func NewMobileAppDiscovery() discovery_kit_sdk.TargetDiscovery {
+	discovery := &mobileAppDiscovery{}
+	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsInterval(context.Background(), 1*time.Minute),
+	)
+}
+
+func (d *mobileAppDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id: MobileAppTargetId,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			CallInterval: new("1m"),
+		},
+	}
+}
+
+func (d *mobileAppDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       MobileAppTargetId,
+		Label:    discovery_kit_api.PluralLabel{One: "Instana Mobile App", Other: "Instana Mobile Apps"},
+		Category: new("monitoring"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     new(eumIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "steadybit.label"},
+				{Attribute: "instana.mobile-app.platform"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{
+					Attribute: "steadybit.label",
+					Direction: "ASC",
+				},
+			},
+		},
+	}
+}
+
+func (d *mobileAppDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDescription {
+	return []discovery_kit_api.AttributeDescription{
+		{
+			Attribute: "instana.mobile-app.id",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana mobile app id",
+				Other: "Instana mobile app ids",
+			},
+		},
+		{
+			Attribute: "instana.mobile-app.label",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana mobile app label",
+				Other: "Instana mobile app labels",
+			},
+		},
+		{
+			Attribute: "instana.mobile-app.platform",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana mobile app platform",
+				Other: "Instana mobile app platforms",
+			},
+		},
+	}
+}
+
+func (d *mobileAppDiscovery) DiscoverTargets(ctx context.Context) ([]discovery_kit_api.Target, error) {
+	return getAllMobileApps(ctx, &config.Config), nil
+}
+
+type GetMobileAppsApi interface {
+	GetMobileApps(ctx context.Context) ([]types.MobileApp, error)
+}
+
+func getAllMobileApps(ctx context.Context, api GetMobileAppsApi) []discovery_kit_api.Target {
+	start := time.Now()
+	mobileApps, err := api.GetMobileApps(ctx)
+	if err != nil {
+		log.Err(err).Msg("Failed to get mobile apps from Instana.")
+		return []discovery_kit_api.Target{}
+	}
+
+	result := make([]discovery_kit_api.Target, 0, len(mobileApps))
+	for _, mobileApp := range mobileApps {
+		attributes := map[string][]string{
+			"steadybit.label":          {mobileApp.Name},
+			"instana.mobile-app.id":    {mobileApp.Id},
+			"instana.mobile-app.label": {mobileApp.Name},
+		}
+		if mobileApp.Platform != "" {
+			attributes["instana.mobile-app.platform"] = []string{mobileApp.Platform}
+		}
+		result = append(result, discovery_kit_api.Target{
+			Id:         mobileApp.Id,
+			Label:      mobileApp.Name,
+			TargetType: MobileAppTargetId,
+			Attributes: attributes,
+		})
+	}
+	log.Debug().Msgf("Discovery took %s, returning %d mobile apps.", time.Since(start), len(result))
+	return result
+}
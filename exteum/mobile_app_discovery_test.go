@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package exteum
+
+import (
+	"context"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func (m *instanaApiMock) GetMobileApps(ctx context.Context) ([]types.MobileApp, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]types.MobileApp), args.Error(1)
+}
+
+func TestMobileAppsAreDiscovered(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetMobileApps", mock.Anything).Return([]types.MobileApp{
+		{Id: "app-1", Name: "shop ios", Platform: "iOS"},
+		{Id: "app-2", Name: "shop legacy"},
+	}, nil)
+
+	// When
+	targets := getAllMobileApps(context.Background(), mockedApi)
+
+	// Then
+	require.Len(t, targets, 2)
+	require.Equal(t, MobileAppTargetId, targets[0].TargetType)
+	require.Equal(t, []string{"app-1"}, targets[0].Attributes["instana.mobile-app.id"])
+	require.Equal(t, []string{"shop ios"}, targets[0].Attributes["instana.mobile-app.label"])
+	require.Equal(t, []string{"iOS"}, targets[0].Attributes["instana.mobile-app.platform"])
+	require.NotContains(t, targets[1].Attributes, "instana.mobile-app.platform")
+}
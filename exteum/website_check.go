@@ -0,0 +1,413 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package exteum
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-instana/config"
+	"github.com/steadybit/extension-instana/extcommon"
+	"github.com/steadybit/extension-instana/types"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"strings"
+	"time"
+)
+
+type WebsiteCheckAction struct{}
+
+// Make sure action implements all required interfaces
+var (
+	_ action_kit_sdk.Action[WebsiteCheckState]           = (*WebsiteCheckAction)(nil)
+	_ action_kit_sdk.ActionWithStatus[WebsiteCheckState] = (*WebsiteCheckAction)(nil)
+)
+
+type WebsiteCheckState struct {
+	Start                   time.Time
+	End                     time.Time
+	WebsiteId               string
+	WebsiteLabel            string
+	Granularity             int
+	PageLoadTimeAggregation string
+	MaxPageLoadTime         *float64
+	MaxJsErrors             *float64
+	MaxJsErrorRate          *float64
+	MaxHttpErrors           *float64
+	MinPageLoads            *float64
+	ConditionCheckMode      string
+	ConditionCheckSuccess   bool
+}
+
+// WebsiteSample holds the beacon metrics of a website within one granularity window.
+type WebsiteSample struct {
+	// PageLoadTime in milliseconds, nil if there were no page loads
+	PageLoadTime *float64
+	PageLoads    float64
+	JsErrors     float64
+	// JsErrorRate is the share of JavaScript errors per page load in percent, nil if there were no page loads
+	JsErrorRate *float64
+	HttpErrors  float64
+}
+
+func NewWebsiteCheckAction() action_kit_sdk.Action[WebsiteCheckState] {
+	return &WebsiteCheckAction{}
+}
+
+func (m *WebsiteCheckAction) NewEmptyState() WebsiteCheckState {
+	return WebsiteCheckState{}
+}
+
+func (m *WebsiteCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          WebsiteCheckActionId,
+		Label:       "Website Check",
+		Description: "Checks the page load time, JavaScript errors and error rate, HTTP errors and page loads of a website monitored by Instana EUM.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        new(eumIcon),
+		TargetSelection: new(action_kit_api.TargetSelection{
+			TargetType: WebsiteTargetId,
+			SelectionTemplates: new([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label: "website label",
+					Query: "instana.website.label=\"\"",
+				},
+			}),
+		}),
+		Technology:  new("Instana"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  new(""),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: new("30s"),
+				Order:        new(1),
+				Required:     new(true),
+			},
+			{
+				Name:        "pageLoadTimeAggregation",
+				Label:       "Page Load Time Aggregation",
+				Description: new("How the page load times are aggregated."),
+				Type:        action_kit_api.ActionParameterTypeString,
+				Options: new([]action_kit_api.ParameterOption{
+					action_kit_api.ExplicitParameterOption{Label: "Mean", Value: "MEAN"},
+					action_kit_api.ExplicitParameterOption{Label: "90th percentile", Value: "P90"},
+					action_kit_api.ExplicitParameterOption{Label: "95th percentile", Value: "P95"},
+					action_kit_api.ExplicitParameterOption{Label: "99th percentile", Value: "P99"},
+				}),
+				DefaultValue: new("P90"),
+				Order:        new(2),
+				Required:     new(true),
+			},
+			{
+				Name:        "maxPageLoadTime",
+				Label:       "Max Page Load Time (ms)",
+				Description: new("Fail if the aggregated page load time exceeds this value. Leave empty to not check the page load time."),
+				Type:        action_kit_api.ActionParameterTypeInteger,
+				Order:       new(3),
+				Required:    new(false),
+			},
+			{
+				Name:        "maxJsErrors",
+				Label:       "Max JavaScript Errors",
+				Description: new("Fail if more JavaScript errors are reported within the granularity window. Leave empty to not check JavaScript errors."),
+				Type:        action_kit_api.ActionParameterTypeInteger,
+				Order:       new(4),
+				Required:    new(false),
+			},
+			{
+				Name:        "maxJsErrorRate",
+				Label:       "Max JavaScript Error Rate",
+				Description: new("Fail if the JavaScript errors per page load within the granularity window exceed this share. Leave empty to not check the JavaScript error rate."),
+				Type:        action_kit_api.ActionParameterTypePercentage,
+				Order:       new(5),
+				Required:    new(false),
+			},
+			{
+				Name:        "maxHttpErrors",
+				Label:       "Max HTTP Errors",
+				Description: new("Fail if more HTTP requests with a status code of 400 or above are reported within the granularity window. Leave empty to not check HTTP errors."),
+				Type:        action_kit_api.ActionParameterTypeInteger,
+				Order:       new(6),
+				Required:    new(false),
+			},
+			{
+				Name:        "minPageLoads",
+				Label:       "Min Page Loads",
+				Description: new("Fail if fewer page load beacons are reported within the granularity window. Leave empty to not check the page loads."),
+				Type:        action_kit_api.ActionParameterTypeInteger,
+				Order:       new(7),
+				Required:    new(false),
+			},
+			extcommon.ConditionCheckModeParameter(8),
+			extcommon.GranularityParameter(9),
+			{
+				Name:        "websiteId",
+				Label:       "Website",
+				Description: new("Id of the website in Instana, used if the target has no website id. Kept for experiments created before websites were selected as targets."),
+				Type:        action_kit_api.ActionParameterTypeString,
+				Order:       new(10),
+				Required:    new(false),
+				Advanced:    new(true),
+			},
+		},
+		Widgets: new([]action_kit_api.Widget{
+			action_kit_api.LineChartWidget{
+				Type:  action_kit_api.ComSteadybitWidgetLineChart,
+				Title: "Instana Website Metrics",
+				Identity: action_kit_api.LineChartWidgetIdentityConfig{
+					MetricName: "instana_website_metrics",
+					From:       "metric",
+					Mode:       action_kit_api.ComSteadybitWidgetLineChartIdentityModeWidgetPerValue,
+				},
+				Grouping: new(action_kit_api.LineChartWidgetGroupingConfig{
+					ShowSummary: new(true),
+					Groups: []action_kit_api.LineChartWidgetGroup{
+						{
+							Title: "Threshold violated",
+							Color: "danger",
+							Matcher: action_kit_api.LineChartWidgetGroupMatcherKeyEqualsValue{
+								Type:  action_kit_api.ComSteadybitWidgetLineChartGroupMatcherKeyEqualsValue,
+								Key:   "violated",
+								Value: "true",
+							},
+						},
+						{
+							Title: "Thresholds met",
+							Color: "success",
+							Matcher: action_kit_api.LineChartWidgetGroupMatcherFallback{
+								Type: action_kit_api.ComSteadybitWidgetLineChartGroupMatcherFallback,
+							},
+						},
+					},
+				}),
+				Tooltip: new(action_kit_api.LineChartWidgetTooltipConfig{
+					MetricValueTitle: new("Value"),
+					AdditionalContent: []action_kit_api.LineChartWidgetTooltipContent{
+						{From: "website", Title: "Website"},
+						{From: "unit", Title: "Unit"},
+					},
+				}),
+			},
+		}),
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: new(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: new("5s"),
+		}),
+	}
+}
+
+func (m *WebsiteCheckAction) Prepare(_ context.Context, state *WebsiteCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	var attributes map[string][]string
+	if request.Target != nil {
+		attributes = request.Target.Attributes
+	}
+	if websiteIds := attributes["instana.website.id"]; len(websiteIds) > 0 {
+		state.WebsiteId = websiteIds[0]
+	} else {
+		state.WebsiteId = strings.TrimSpace(extutil.ToString(request.Config["websiteId"]))
+	}
+	if state.WebsiteId == "" {
+		return nil, extension_kit.ToError("Target is missing the 'instana.website.id' attribute.", nil)
+	}
+	state.WebsiteLabel = state.WebsiteId
+	if labels := attributes["instana.website.label"]; len(labels) > 0 {
+		state.WebsiteLabel = labels[0]
+	}
+
+	duration := extutil.ToInt64(request.Config["duration"])
+	state.Start = time.Now()
+	state.End = time.Now().Add(time.Millisecond * time.Duration(duration))
+
+	state.Granularity = extutil.ToInt(request.Config["granularity"])
+	if state.Granularity <= 0 {
+		return nil, extension_kit.ToError(fmt.Sprintf("Invalid granularity: '%v'.", request.Config["granularity"]), nil)
+	}
+	state.PageLoadTimeAggregation = extutil.ToString(request.Config["pageLoadTimeAggregation"])
+	if state.PageLoadTimeAggregation == "" {
+		state.PageLoadTimeAggregation = "P90"
+	}
+	state.MaxPageLoadTime = extcommon.OptionalFloat(request.Config, "maxPageLoadTime")
+	state.MaxJsErrors = extcommon.OptionalFloat(request.Config, "maxJsErrors")
+	state.MaxJsErrorRate = extcommon.OptionalFloat(request.Config, "maxJsErrorRate")
+	state.MaxHttpErrors = extcommon.OptionalFloat(request.Config, "maxHttpErrors")
+	state.MinPageLoads = extcommon.OptionalFloat(request.Config, "minPageLoads")
+	if request.Config["conditionCheckMode"] != nil {
+		state.ConditionCheckMode = fmt.Sprintf("%v", request.Config["conditionCheckMode"])
+	}
+	return nil, nil
+}
+
+func (m *WebsiteCheckAction) Start(ctx context.Context, state *WebsiteCheckState) (*action_kit_api.StartResult, error) {
+	statusResult, err := WebsiteCheckStatus(ctx, state, &config.Config)
+	if statusResult == nil {
+		return nil, err
+	}
+	startResult := action_kit_api.StartResult{
+		Artifacts: statusResult.Artifacts,
+		Error:     statusResult.Error,
+		Messages:  statusResult.Messages,
+		Metrics:   statusResult.Metrics,
+	}
+	return &startResult, err
+}
+
+func (m *WebsiteCheckAction) Status(ctx context.Context, state *WebsiteCheckState) (*action_kit_api.StatusResult, error) {
+	return WebsiteCheckStatus(ctx, state, &config.Config)
+}
+
+type WebsiteMetricsApi interface {
+	GetWebsiteMetrics(ctx context.Context, request types.WebsiteMetricsRequest) (*types.WebsiteMetricsResponse, error)
+}
+
+func WebsiteCheckStatus(ctx context.Context, state *WebsiteCheckState, api WebsiteMetricsApi) (*action_kit_api.StatusResult, error) {
+	now := time.Now()
+	sample, err := getWebsiteSample(ctx, state, api, now)
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to get website metrics from Instana.", err)
+	}
+
+	violations := state.violations(*sample)
+	completed := now.After(state.End)
+	checkError := extcommon.EvaluateCondition(state.ConditionCheckMode, violations, completed, &state.ConditionCheckSuccess)
+
+	return &action_kit_api.StatusResult{
+		Completed: completed,
+		Error:     checkError,
+		Metrics:   new(toMetrics(state.WebsiteLabel, *sample, violations, now)),
+	}, nil
+}
+
+func getWebsiteSample(ctx context.Context, state *WebsiteCheckState, api WebsiteMetricsApi, now time.Time) (*WebsiteSample, error) {
+	timeFrame := types.TimeFrame{
+		To:         now.UnixMilli(),
+		WindowSize: int64(state.Granularity) * 1000,
+	}
+	websiteFilter := types.TagFilter{
+		Type:     "TAG_FILTER",
+		Name:     "beacon.website.id",
+		Operator: "EQUALS",
+		Entity:   "NOT_APPLICABLE",
+		Value:    state.WebsiteId,
+	}
+	beaconCount := types.MetricConfig{Metric: "beaconCount", Aggregation: "SUM", Granularity: state.Granularity}
+
+	pageLoads, err := api.GetWebsiteMetrics(ctx, types.WebsiteMetricsRequest{
+		Type: "PAGELOAD",
+		Metrics: []types.MetricConfig{
+			{Metric: "pageLoadTime", Aggregation: state.PageLoadTimeAggregation, Granularity: state.Granularity},
+			beaconCount,
+		},
+		TagFilterExpression: websiteFilter,
+		TimeFrame:           timeFrame,
+	})
+	if err != nil {
+		return nil, err
+	}
+	jsErrors, err := api.GetWebsiteMetrics(ctx, types.WebsiteMetricsRequest{
+		Type:                "ERROR",
+		Metrics:             []types.MetricConfig{beaconCount},
+		TagFilterExpression: websiteFilter,
+		TimeFrame:           timeFrame,
+	})
+	if err != nil {
+		return nil, err
+	}
+	httpErrors, err := api.GetWebsiteMetrics(ctx, types.WebsiteMetricsRequest{
+		Type:    "HTTPREQUEST",
+		Metrics: []types.MetricConfig{beaconCount},
+		TagFilterExpression: types.TagFilter{
+			Type:            "EXPRESSION",
+			LogicalOperator: "AND",
+			Elements: []types.TagFilter{
+				websiteFilter,
+				{Type: "TAG_FILTER", Name: "beacon.http.status", Operator: "GREATER_OR_EQUAL_THAN", Entity: "NOT_APPLICABLE", Value: 400},
+			},
+		},
+		TimeFrame: timeFrame,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sample := WebsiteSample{}
+	sample.PageLoads, _ = extcommon.LatestValue(pageLoads.Metrics, "beaconCount.sum")
+	if sample.PageLoads > 0 {
+		if pageLoadTime, ok := extcommon.LatestValue(pageLoads.Metrics, "pageLoadTime."+strings.ToLower(state.PageLoadTimeAggregation)); ok {
+			sample.PageLoadTime = new(pageLoadTime)
+		}
+	}
+	sample.JsErrors, _ = extcommon.LatestValue(jsErrors.Metrics, "beaconCount.sum")
+	if sample.PageLoads > 0 {
+		sample.JsErrorRate = new(sample.JsErrors / sample.PageLoads * 100)
+	}
+	sample.HttpErrors, _ = extcommon.LatestValue(httpErrors.Metrics, "beaconCount.sum")
+	return &sample, nil
+}
+
+func (state *WebsiteCheckState) violations(sample WebsiteSample) []string {
+	violations := make([]string, 0)
+	if state.MaxPageLoadTime != nil && sample.PageLoadTime != nil && *sample.PageLoadTime > *state.MaxPageLoadTime {
+		violations = append(violations, fmt.Sprintf("page load time %.0fms exceeds %.0fms", *sample.PageLoadTime, *state.MaxPageLoadTime))
+	}
+	if state.MaxJsErrors != nil && sample.JsErrors > *state.MaxJsErrors {
+		violations = append(violations, fmt.Sprintf("%.0f JavaScript errors exceed %.0f", sample.JsErrors, *state.MaxJsErrors))
+	}
+	if state.MaxJsErrorRate != nil && sample.JsErrorRate != nil && *sample.JsErrorRate > *state.MaxJsErrorRate {
+		violations = append(violations, fmt.Sprintf("JavaScript error rate %.2f%% exceeds %.2f%%", *sample.JsErrorRate, *state.MaxJsErrorRate))
+	}
+	if state.MaxHttpErrors != nil && sample.HttpErrors > *state.MaxHttpErrors {
+		violations = append(violations, fmt.Sprintf("%.0f HTTP errors exceed %.0f", sample.HttpErrors, *state.MaxHttpErrors))
+	}
+	if state.MinPageLoads != nil && sample.PageLoads < *state.MinPageLoads {
+		violations = append(violations, fmt.Sprintf("%.0f page loads are below %.0f", sample.PageLoads, *state.MinPageLoads))
+	}
+	return violations
+}
+
+func toMetrics(website string, sample WebsiteSample, violations []string, now time.Time) []action_kit_api.Metric {
+	values := map[string]*float64{
+		metricPageLoadTime: sample.PageLoadTime,
+		metricPageLoads:    new(sample.PageLoads),
+		metricJsErrors:     new(sample.JsErrors),
+		metricJsErrorRate:  sample.JsErrorRate,
+		metricHttpErrors:   new(sample.HttpErrors),
+	}
+	units := map[string]string{
+		metricPageLoadTime: "ms",
+		metricPageLoads:    "beacons",
+		metricJsErrors:     "errors",
+		metricJsErrorRate:  "%",
+		metricHttpErrors:   "requests",
+	}
+	violated := "false"
+	if len(violations) > 0 {
+		violated = "true"
+	}
+
+	metrics := make([]action_kit_api.Metric, 0, len(values))
+	for _, metric := range []string{metricPageLoadTime, metricPageLoads, metricJsErrors, metricJsErrorRate, metricHttpErrors} {
+		if values[metric] == nil {
+			continue
+		}
+		metrics = append(metrics, action_kit_api.Metric{
+			Name: new("instana_website_metrics"),
+			Metric: map[string]string{
+				"metric":   metric,
+				"unit":     units[metric],
+				"website":  website,
+				"violated": violated,
+			},
+			Timestamp: now,
+			Value:     *values[metric],
+		})
+	}
+	return metrics
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package exteum
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type instanaApiMock struct {
+	mock.Mock
+}
+
+func (m *instanaApiMock) GetWebsiteMetrics(ctx context.Context, request types.WebsiteMetricsRequest) (*types.WebsiteMetricsResponse, error) {
+	args := m.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.WebsiteMetricsResponse), args.Error(1)
+}
+
+func beaconType(beaconType string) any {
+	return mock.MatchedBy(func(request types.WebsiteMetricsRequest) bool { return request.Type == beaconType })
+}
+
+func mockWebsiteMetrics(mockedApi *instanaApiMock, pageLoadTime float64, pageLoads float64, jsErrors float64, httpErrors float64) {
+	mockedApi.On("GetWebsiteMetrics", mock.Anything, beaconType("PAGELOAD")).Return(&types.WebsiteMetricsResponse{
+		Metrics: map[string][][]float64{
+			"pageLoadTime.p90": {{1700000000000, pageLoadTime}},
+			"beaconCount.sum":  {{1700000000000, pageLoads}},
+		},
+	}, nil)
+	mockedApi.On("GetWebsiteMetrics", mock.Anything, beaconType("ERROR")).Return(&types.WebsiteMetricsResponse{
+		Metrics: map[string][][]float64{"beaconCount.sum": {{1700000000000, jsErrors}}},
+	}, nil)
+	mockedApi.On("GetWebsiteMetrics", mock.Anything, beaconType("HTTPREQUEST")).Return(&types.WebsiteMetricsResponse{
+		Metrics: map[string][][]float64{"beaconCount.sum": {{1700000000000, httpErrors}}},
+	}, nil)
+}
+
+func TestWebsiteCheckThresholdsMet(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockWebsiteMetrics(mockedApi, 1800, 120, 2, 0)
+	state := WebsiteCheckState{
+		End:                     time.Now().Add(time.Minute),
+		WebsiteId:               "website-1",
+		WebsiteLabel:            "shop",
+		Granularity:             60,
+		PageLoadTimeAggregation: "P90",
+		MaxPageLoadTime:         new(3000.0),
+		MaxJsErrors:             new(5.0),
+		MaxJsErrorRate:          new(2.0),
+		MinPageLoads:            new(10.0),
+		ConditionCheckMode:      "allTheTime",
+	}
+
+	// When
+	result, err := WebsiteCheckStatus(context.Background(), &state, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.Nil(t, result.Error)
+	require.Len(t, *result.Metrics, 5)
+	for _, metric := range *result.Metrics {
+		require.Equal(t, "shop", metric.Metric["website"])
+		require.Equal(t, "false", metric.Metric["violated"])
+	}
+	request := mockedApi.Calls[0].Arguments.Get(1).(types.WebsiteMetricsRequest)
+	require.Equal(t, "beacon.website.id", request.TagFilterExpression.Name)
+	require.Equal(t, "website-1", request.TagFilterExpression.Value)
+}
+
+func TestWebsiteCheckReportsViolations(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockWebsiteMetrics(mockedApi, 4200, 3, 12, 7)
+	state := WebsiteCheckState{
+		End:                     time.Now().Add(time.Minute),
+		WebsiteId:               "website-1",
+		WebsiteLabel:            "shop",
+		Granularity:             60,
+		PageLoadTimeAggregation: "P90",
+		MaxPageLoadTime:         new(3000.0),
+		MaxJsErrors:             new(5.0),
+		MaxJsErrorRate:          new(2.0),
+		MaxHttpErrors:           new(0.0),
+		MinPageLoads:            new(10.0),
+		ConditionCheckMode:      "allTheTime",
+	}
+
+	// When
+	result, err := WebsiteCheckStatus(context.Background(), &state, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, result.Error)
+	require.Equal(t, "Thresholds violated: page load time 4200ms exceeds 3000ms, 12 JavaScript errors exceed 5, JavaScript error rate 400.00% exceeds 2.00%, 7 HTTP errors exceed 0, 3 page loads are below 10.", result.Error.Title)
+}
+
+func TestWebsiteCheckFallsBackToWebsiteIdParameter(t *testing.T) {
+	// Given
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]any{
+			"duration":    60000,
+			"granularity": 60,
+			"websiteId":   "website-1",
+		},
+	}
+	state := WebsiteCheckState{}
+
+	// When
+	_, err := NewWebsiteCheckAction().Prepare(context.Background(), &state, request)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, "website-1", state.WebsiteId)
+	require.Equal(t, "website-1", state.WebsiteLabel)
+}
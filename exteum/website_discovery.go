@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package exteum
+
+import (
+	"context"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-instana/config"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/steadybit/extension-kit/extbuild"
+	"time"
+)
+
+type websiteDiscovery struct {
+}
+
+var (
+	_ discovery_kit_sdk.TargetDescriber    = (*websiteDiscovery)(nil)
+	_ discovery_kit_sdk.AttributeDescriber = (*websiteDiscovery)(nil)
+)
+
+func NewWebsiteDiscovery() discovery_kit_sdk.TargetDiscovery {
+	discovery := &websiteDiscovery{}
+	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsInterval(context.Background(), 1*time.Minute),
+	)
+}
+
+func (d *websiteDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id: WebsiteTargetId,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			CallInterval: new("1m"),
+		},
+	}
+}
+
+func (d *websiteDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       WebsiteTargetId,
+		Label:    discovery_kit_api.PluralLabel{One: "Instana Website", Other: "Instana Websites"},
+		Category: new("monitoring"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     new(eumIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "steadybit.label"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{
+					Attribute: "steadybit.label",
+					Direction: "ASC",
+				},
+			},
+		},
+	}
+}
+
+func (d *websiteDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDescription {
+	return []discovery_kit_api.AttributeDescription{
+		{
+			Attribute: "instana.website.id",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana website id",
+				Other: "Instana website ids",
+			},
+		},
+		{
+			Attribute: "instana.website.label",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana website label",
+				Other: "Instana website labels",
+			},
+		},
+	}
+}
+
+func (d *websiteDiscovery) DiscoverTargets(ctx context.Context) ([]discovery_kit_api.Target, error) {
+	return getAllWebsites(ctx, &config.Config), nil
+}
+
+type GetWebsitesApi interface {
+	GetWebsites(ctx context.Context) ([]types.Website, error)
+}
+
+func getAllWebsites(ctx context.Context, api GetWebsitesApi) []discovery_kit_api.Target {
+	start := time.Now()
+	websites, err := api.GetWebsites(ctx)
+	if err != nil {
+		log.Err(err).Msg("Failed to get websites from Instana.")
+		return []discovery_kit_api.Target{}
+	}
+
+	result := make([]discovery_kit_api.Target, 0, len(websites))
+	for _, website := range websites {
+		result = append(result, discovery_kit_api.Target{
+			Id:         website.Id,
+			Label:      website.Name,
+			TargetType: WebsiteTargetId,
+			Attributes: map[string][]string{
+				"steadybit.label":       {website.Name},
+				"instana.website.id":    {website.Id},
+				"instana.website.label": {website.Name},
+			},
+		})
+	}
+	log.Debug().Msgf("Discovery took %s, returning %d websites.", time.Since(start), len(result))
+	return result
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package exteum
+
+import (
+	"context"
+	"errors"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func (m *instanaApiMock) GetWebsites(ctx context.Context) ([]types.Website, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]types.Website), args.Error(1)
+}
+
+func TestWebsitesAreDiscovered(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetWebsites", mock.Anything).Return([]types.Website{{Id: "website-1", Name: "shop"}}, nil)
+
+	// When
+	targets := getAllWebsites(context.Background(), mockedApi)
+
+	// Then
+	require.Len(t, targets, 1)
+	require.Equal(t, "website-1", targets[0].Id)
+	require.Equal(t, WebsiteTargetId, targets[0].TargetType)
+	require.Equal(t, []string{"shop"}, targets[0].Attributes["instana.website.label"])
+	require.Equal(t, []string{"website-1"}, targets[0].Attributes["instana.website.id"])
+}
+
+func TestWebsiteDiscoveryReturnsEmptyResultOnError(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetWebsites", mock.Anything).Return(nil, errors.New("oops"))
+
+	// When
+	targets := getAllWebsites(context.Background(), mockedApi)
+
+	// Then
+	require.Empty(t, targets)
+}
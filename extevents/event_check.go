@@ -201,15 +201,11 @@ func (m *EventCheckAction) Prepare(ctx context.Context, state *EventCheckState,
 
 	if request.Config["eventSeverityFilter"] != nil {
 		severityFilter := fmt.Sprintf("%v", request.Config["eventSeverityFilter"])
-		if severityFilter == severityInfo {
-			state.EventSeverityFilter = -1
-		} else if severityFilter == severityWarning {
-			state.EventSeverityFilter = 5
-		} else if severityFilter == severityCritical {
-			state.EventSeverityFilter = 10
-		} else {
+		severity, ok := ToSeverity(severityFilter)
+		if !ok {
 			return nil, extension_kit.ToError(fmt.Sprintf("Unknown Event Severity Filter: '%s'.", severityFilter), nil)
 		}
+		state.EventSeverityFilter = severity
 	} else {
 		return nil, extension_kit.ToError("Event Severity Filter is required.", nil)
 	}
@@ -345,6 +341,19 @@ func eventsToMetrics(events []types.Event, now time.Time) *action_kit_api.Metric
 	return new(metrics)
 }
 
+// ToSeverity maps a severity filter name ('info', 'warning' or 'critical') to the Instana event severity.
+func ToSeverity(severityFilter string) (int, bool) {
+	switch severityFilter {
+	case severityInfo:
+		return -1, true
+	case severityWarning:
+		return 5, true
+	case severityCritical:
+		return 10, true
+	}
+	return 0, false
+}
+
 func getState(severity int) string {
 	if severity == -1 {
 		return "info"
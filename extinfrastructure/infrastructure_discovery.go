@@ -0,0 +1,272 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extinfrastructure
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-instana/config"
+	"github.com/steadybit/extension-instana/extcommon"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/steadybit/extension-kit/extbuild"
+	"slices"
+	"strings"
+	"time"
+)
+
+const snapshotDetailsBatchSize = 100
+
+type infrastructureDiscovery struct {
+	api GetInfrastructureEntitiesApi
+	// lastKnownGood is the result of the last complete discovery, served again while Instana requests fail.
+	lastKnownGood extcommon.LastKnownGoodTargets
+}
+
+var (
+	_ discovery_kit_sdk.TargetDescriber    = (*infrastructureDiscovery)(nil)
+	_ discovery_kit_sdk.AttributeDescriber = (*infrastructureDiscovery)(nil)
+)
+
+func NewInfrastructureDiscovery() discovery_kit_sdk.TargetDiscovery {
+	discovery := &infrastructureDiscovery{api: &config.Config}
+	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsInterval(context.Background(), 5*time.Minute),
+	)
+}
+
+func (d *infrastructureDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id: InfrastructureEntityTargetId,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			CallInterval: new("5m"),
+		},
+	}
+}
+
+func (d *infrastructureDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       InfrastructureEntityTargetId,
+		Label:    discovery_kit_api.PluralLabel{One: "Instana Infrastructure Entity", Other: "Instana Infrastructure Entities"},
+		Category: new("monitoring"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     new(infrastructureIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "steadybit.label"},
+				{Attribute: "instana.infrastructure.plugin"},
+				{Attribute: "instana.kubernetes.cluster"},
+				{Attribute: "instana.kubernetes.namespace"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{
+					Attribute: "steadybit.label",
+					Direction: "ASC",
+				},
+			},
+		},
+	}
+}
+
+func (d *infrastructureDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDescription {
+	return []discovery_kit_api.AttributeDescription{
+		{
+			Attribute: "instana.infrastructure.snapshot-id",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana snapshot id",
+				Other: "Instana snapshot ids",
+			},
+		},
+		{
+			Attribute: "instana.infrastructure.plugin",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana infrastructure plugin",
+				Other: "Instana infrastructure plugins",
+			},
+		},
+		{
+			Attribute: "instana.infrastructure.label",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana infrastructure label",
+				Other: "Instana infrastructure labels",
+			},
+		},
+		{
+			Attribute: "instana.infrastructure.host",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana agent host id",
+				Other: "Instana agent host ids",
+			},
+		},
+		{
+			Attribute: "instana.infrastructure.tag",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana infrastructure tag",
+				Other: "Instana infrastructure tags",
+			},
+		},
+		{
+			Attribute: "instana.host.name",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana host name",
+				Other: "Instana host names",
+			},
+		},
+		{
+			Attribute: "instana.kubernetes.cluster",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana Kubernetes cluster",
+				Other: "Instana Kubernetes clusters",
+			},
+		},
+		{
+			Attribute: "instana.kubernetes.namespace",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana Kubernetes namespace",
+				Other: "Instana Kubernetes namespaces",
+			},
+		},
+		{
+			Attribute: "instana.kubernetes.deployment",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana Kubernetes deployment",
+				Other: "Instana Kubernetes deployments",
+			},
+		},
+		{
+			Attribute: "instana.infrastructure.stale-since",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana infrastructure entity stale since",
+				Other: "Instana infrastructure entities stale since",
+			},
+		},
+	}
+}
+
+func (d *infrastructureDiscovery) DiscoverTargets(ctx context.Context) ([]discovery_kit_api.Target, error) {
+	return d.discover(ctx, config.Config.DiscoveryInfrastructurePlugins)
+}
+
+// discover returns the current infrastructure entities or, if the discovery fails, the last complete result marked
+// with the instana.infrastructure.stale-since attribute.
+func (d *infrastructureDiscovery) discover(ctx context.Context, plugins []string) ([]discovery_kit_api.Target, error) {
+	targets, err := getAllInfrastructureEntities(ctx, d.api, plugins)
+	return d.lastKnownGood.Resolve(targets, err, "instana.infrastructure.stale-since", "infrastructure entities")
+}
+
+type GetInfrastructureEntitiesApi interface {
+	GetSnapshots(ctx context.Context, plugin string) ([]types.Snapshot, error)
+	GetSnapshotDetails(ctx context.Context, snapshotIds []string) ([]types.SnapshotDetails, error)
+}
+
+// getAllInfrastructureEntities searches the snapshots of the given plugins and returns one target per snapshot,
+// enriched with the tags and plugin specific metadata of the snapshot details. Any failed request fails the whole
+// discovery to not publish entities of some plugins only or without their metadata.
+func getAllInfrastructureEntities(ctx context.Context, api GetInfrastructureEntitiesApi, plugins []string) ([]discovery_kit_api.Target, error) {
+	start := time.Now()
+	snapshots := make([]types.Snapshot, 0)
+	for _, plugin := range plugins {
+		plugin = strings.TrimSpace(plugin)
+		if plugin == "" {
+			continue
+		}
+		pluginSnapshots, err := api.GetSnapshots(ctx, plugin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get snapshots of plugin %s from Instana: %w", plugin, err)
+		}
+		snapshots = append(snapshots, pluginSnapshots...)
+	}
+
+	snapshotIds := make([]string, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		snapshotIds = append(snapshotIds, snapshot.SnapshotId)
+	}
+	details := make(map[string]types.SnapshotDetails, len(snapshots))
+	for batch := range slices.Chunk(snapshotIds, snapshotDetailsBatchSize) {
+		batchDetails, err := api.GetSnapshotDetails(ctx, batch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get snapshot details from Instana: %w", err)
+		}
+		for _, detail := range batchDetails {
+			details[detail.SnapshotId] = detail
+		}
+	}
+
+	result := make([]discovery_kit_api.Target, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		result = append(result, toTarget(snapshot, details[snapshot.SnapshotId]))
+	}
+	log.Debug().Msgf("Discovery took %s, returning %d infrastructure entities.", time.Since(start), len(result))
+	return result, nil
+}
+
+func toTarget(snapshot types.Snapshot, details types.SnapshotDetails) discovery_kit_api.Target {
+	label := snapshot.Label
+	if label == "" {
+		label = details.Label
+	}
+	if label == "" {
+		label = snapshot.SnapshotId
+	}
+
+	attributes := make(map[string][]string)
+	attributes["steadybit.label"] = []string{label}
+	attributes["instana.infrastructure.snapshot-id"] = []string{snapshot.SnapshotId}
+	attributes["instana.infrastructure.plugin"] = []string{snapshot.Plugin}
+	attributes["instana.infrastructure.label"] = []string{label}
+	if snapshot.Host != "" {
+		attributes["instana.infrastructure.host"] = []string{snapshot.Host}
+	}
+	if len(details.Tags) > 0 {
+		attributes["instana.infrastructure.tag"] = details.Tags
+	}
+
+	setAttribute := func(key string, value string) {
+		if value != "" {
+			attributes[key] = []string{value}
+		}
+	}
+	switch snapshot.Plugin {
+	case "host":
+		setAttribute("instana.host.name", firstNonEmpty(snapshotData(details, "hostname"), label))
+	case "kubernetesCluster":
+		setAttribute("instana.kubernetes.cluster", firstNonEmpty(snapshotData(details, "name"), label))
+	case "kubernetesNamespace":
+		setAttribute("instana.kubernetes.cluster", snapshotData(details, "clusterName", "cluster"))
+		setAttribute("instana.kubernetes.namespace", firstNonEmpty(snapshotData(details, "name"), label))
+	case "kubernetesDeployment":
+		setAttribute("instana.kubernetes.cluster", snapshotData(details, "clusterName", "cluster"))
+		setAttribute("instana.kubernetes.namespace", snapshotData(details, "namespace"))
+		setAttribute("instana.kubernetes.deployment", firstNonEmpty(snapshotData(details, "name"), label))
+	}
+
+	return discovery_kit_api.Target{
+		Id:         snapshot.SnapshotId,
+		Label:      label,
+		TargetType: InfrastructureEntityTargetId,
+		Attributes: attributes,
+	}
+}
+
+// snapshotData returns the first non-empty string value of the given keys from the plugin specific snapshot data.
+func snapshotData(details types.SnapshotDetails, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := details.Data[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
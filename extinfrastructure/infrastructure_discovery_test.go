@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extinfrastructure
+
+import (
+	"context"
+	"errors"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type instanaApiMock struct {
+	mock.Mock
+}
+
+func (m *instanaApiMock) GetSnapshots(ctx context.Context, plugin string) ([]types.Snapshot, error) {
+	args := m.Called(ctx, plugin)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]types.Snapshot), args.Error(1)
+}
+
+func (m *instanaApiMock) GetSnapshotDetails(ctx context.Context, snapshotIds []string) ([]types.SnapshotDetails, error) {
+	args := m.Called(ctx, snapshotIds)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]types.SnapshotDetails), args.Error(1)
+}
+
+func TestInfrastructureEntitiesAreDiscoveredPerPlugin(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetSnapshots", mock.Anything, "host").Return([]types.Snapshot{{SnapshotId: "h1", Plugin: "host", Label: "node-1", Host: "agent-1"}}, nil)
+	mockedApi.On("GetSnapshots", mock.Anything, "kubernetesDeployment").Return([]types.Snapshot{{SnapshotId: "d1", Plugin: "kubernetesDeployment", Label: "shop/cart"}}, nil)
+	mockedApi.On("GetSnapshotDetails", mock.Anything, []string{"h1", "d1"}).Return([]types.SnapshotDetails{
+		{SnapshotId: "h1", Tags: []string{"env=prod"}, Data: map[string]any{"hostname": "node-1.internal"}},
+		{SnapshotId: "d1", Data: map[string]any{"clusterName": "prod", "namespace": "shop", "name": "cart"}},
+	}, nil)
+
+	// When
+	targets, err := getAllInfrastructureEntities(context.Background(), mockedApi, []string{"host", " kubernetesDeployment", ""})
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, targets, 2)
+	require.Equal(t, "h1", targets[0].Id)
+	require.Equal(t, []string{"host"}, targets[0].Attributes["instana.infrastructure.plugin"])
+	require.Equal(t, []string{"agent-1"}, targets[0].Attributes["instana.infrastructure.host"])
+	require.Equal(t, []string{"env=prod"}, targets[0].Attributes["instana.infrastructure.tag"])
+	require.Equal(t, []string{"node-1.internal"}, targets[0].Attributes["instana.host.name"])
+	require.Equal(t, "shop/cart", targets[1].Label)
+	require.Equal(t, []string{"d1"}, targets[1].Attributes["instana.infrastructure.snapshot-id"])
+	require.Equal(t, []string{"prod"}, targets[1].Attributes["instana.kubernetes.cluster"])
+	require.Equal(t, []string{"shop"}, targets[1].Attributes["instana.kubernetes.namespace"])
+	require.Equal(t, []string{"cart"}, targets[1].Attributes["instana.kubernetes.deployment"])
+}
+
+func TestFailedSnapshotDetailsFailTheDiscovery(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetSnapshots", mock.Anything, "kubernetesCluster").Return([]types.Snapshot{{SnapshotId: "c1", Plugin: "kubernetesCluster", Label: "prod"}}, nil)
+	mockedApi.On("GetSnapshotDetails", mock.Anything, mock.Anything).Return(nil, errors.New("oops"))
+
+	// When
+	targets, err := getAllInfrastructureEntities(context.Background(), mockedApi, []string{"kubernetesCluster"})
+
+	// Then
+	require.ErrorContains(t, err, "oops")
+	require.Nil(t, targets)
+}
+
+func TestDiscoveryServesLastKnownGoodResultIfAPluginFails(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetSnapshots", mock.Anything, "host").Return([]types.Snapshot{{SnapshotId: "h1", Plugin: "host", Label: "node-1"}}, nil)
+	mockedApi.On("GetSnapshots", mock.Anything, "kubernetesNamespace").Return([]types.Snapshot{{SnapshotId: "n1", Plugin: "kubernetesNamespace", Label: "shop"}}, nil).Once()
+	mockedApi.On("GetSnapshots", mock.Anything, "kubernetesNamespace").Return(nil, errors.New("oops"))
+	mockedApi.On("GetSnapshotDetails", mock.Anything, mock.Anything).Return([]types.SnapshotDetails{}, nil)
+	discovery := &infrastructureDiscovery{api: mockedApi}
+	plugins := []string{"host", "kubernetesNamespace"}
+
+	// When
+	_, err := discovery.discover(context.Background(), plugins)
+	require.NoError(t, err)
+	targets, err := discovery.discover(context.Background(), plugins)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, targets, 2)
+	require.Equal(t, []string{discovery.lastKnownGood.StaleSince().Format(time.RFC3339)}, targets[1].Attributes["instana.infrastructure.stale-since"])
+}
+
+func TestDiscoveryWithoutPreviousResultReturnsError(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetSnapshots", mock.Anything, "host").Return(nil, errors.New("oops"))
+	discovery := &infrastructureDiscovery{api: mockedApi}
+
+	// When
+	targets, err := discovery.discover(context.Background(), []string{"host"})
+
+	// Then
+	require.ErrorContains(t, err, "oops")
+	require.Nil(t, targets)
+}
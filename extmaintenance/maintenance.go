@@ -6,37 +6,131 @@ package extmaintenance
 import (
 	"context"
 	"fmt"
+	"github.com/rs/zerolog/log"
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/action-kit/go/action_kit_sdk"
 	"github.com/steadybit/extension-instana/config"
 	"github.com/steadybit/extension-instana/extapplications"
+	"github.com/steadybit/extension-instana/exteum"
+	"github.com/steadybit/extension-instana/extservices"
 	"github.com/steadybit/extension-instana/types"
 	extension_kit "github.com/steadybit/extension-kit"
 	"github.com/steadybit/extension-kit/extbuild"
 	"github.com/steadybit/extension-kit/extutil"
 	"math"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 )
 
-type CreateMaintenanceWindowAction struct{}
+const (
+	queryModeCombine = "combine"
+	queryModeReplace = "replace"
+	// extensionThreshold is the remaining time of the window at which it is extended by the status calls
+	extensionThreshold = time.Minute
+	// extensionLead is how far the window reaches beyond the status call extending it
+	extensionLead = 3 * time.Minute
+)
+
+// nonInfrastructureQueryKeys are Dynamic Focus Query keys of entities the infrastructure snapshot search doesn't return,
+// so the entities of queries using them can't be counted.
+var nonInfrastructureQueryKeys = []string{"entity.service.", "entity.endpoint.", "entity.website.", "entity.mobileapp."}
+
+type CreateMaintenanceWindowAction struct {
+	target maintenanceWindowTarget
+}
+
+// maintenanceWindowTarget describes a target type a maintenance window can be created for.
+type maintenanceWindowTarget struct {
+	actionId       string
+	label          string
+	targetType     string
+	targetLabel    string
+	idAttribute    string
+	labelAttribute string
+	// queryKey is the Dynamic Focus Query key matching the entities of the target
+	queryKey string
+}
 
 // Make sure action implements all required interfaces
 var (
-	_ action_kit_sdk.Action[CreateMaintenanceWindowState]         = (*CreateMaintenanceWindowAction)(nil)
-	_ action_kit_sdk.ActionWithStop[CreateMaintenanceWindowState] = (*CreateMaintenanceWindowAction)(nil)
+	_ action_kit_sdk.Action[CreateMaintenanceWindowState]           = (*CreateMaintenanceWindowAction)(nil)
+	_ action_kit_sdk.ActionWithStatus[CreateMaintenanceWindowState] = (*CreateMaintenanceWindowAction)(nil)
+	_ action_kit_sdk.ActionWithStop[CreateMaintenanceWindowState]   = (*CreateMaintenanceWindowAction)(nil)
 )
 
 type CreateMaintenanceWindowState struct {
-	ApplicationPerspectiveId string
-	DurationInMillis         int64
-	ExperimentKey            *string
-	ExecutionId              *int
-	MaintenanceWindowId      *string
+	Query            string
+	DurationInMillis int64
+	ExperimentKey    *string
+	ExecutionId      *int
+	// ActionExecutionId is unique per target, keeping the windows of several targets of one experiment run apart
+	ActionExecutionId   string
+	TargetLabel         string
+	MaintenanceWindowId *string
+	Request             *types.CreateMaintenanceWindowRequest
+	FailOnNoEntities    bool
 }
 
 func NewCreateMaintenanceWindowAction() action_kit_sdk.Action[CreateMaintenanceWindowState] {
-	return &CreateMaintenanceWindowAction{}
+	return &CreateMaintenanceWindowAction{target: maintenanceWindowTarget{
+		actionId:       MaintenanceWindowActionId,
+		label:          "Create Maintenance Window",
+		targetType:     extapplications.ApplicationPerspectiveTargetId,
+		targetLabel:    "application perspective",
+		idAttribute:    "instana.application.id",
+		labelAttribute: "instana.application.label",
+		queryKey:       "entity.application.id",
+	}}
+}
+
+func NewCreateServiceMaintenanceWindowAction() action_kit_sdk.Action[CreateMaintenanceWindowState] {
+	return &CreateMaintenanceWindowAction{target: maintenanceWindowTarget{
+		actionId:       ServiceMaintenanceWindowActionId,
+		label:          "Create Service Maintenance Window",
+		targetType:     extservices.ServiceTargetId,
+		targetLabel:    "service",
+		idAttribute:    "instana.service.id",
+		labelAttribute: "instana.service.label",
+		queryKey:       "entity.service.id",
+	}}
+}
+
+func NewCreateEndpointMaintenanceWindowAction() action_kit_sdk.Action[CreateMaintenanceWindowState] {
+	return &CreateMaintenanceWindowAction{target: maintenanceWindowTarget{
+		actionId:       EndpointMaintenanceWindowActionId,
+		label:          "Create Endpoint Maintenance Window",
+		targetType:     extservices.EndpointTargetId,
+		targetLabel:    "endpoint",
+		idAttribute:    "instana.endpoint.id",
+		labelAttribute: "instana.endpoint.label",
+		queryKey:       "entity.endpoint.id",
+	}}
+}
+
+func NewCreateWebsiteMaintenanceWindowAction() action_kit_sdk.Action[CreateMaintenanceWindowState] {
+	return &CreateMaintenanceWindowAction{target: maintenanceWindowTarget{
+		actionId:       WebsiteMaintenanceWindowActionId,
+		label:          "Create Website Maintenance Window",
+		targetType:     exteum.WebsiteTargetId,
+		targetLabel:    "website",
+		idAttribute:    "instana.website.id",
+		labelAttribute: "instana.website.label",
+		queryKey:       "entity.website.id",
+	}}
+}
+
+func NewCreateMobileAppMaintenanceWindowAction() action_kit_sdk.Action[CreateMaintenanceWindowState] {
+	return &CreateMaintenanceWindowAction{target: maintenanceWindowTarget{
+		actionId:       MobileAppMaintenanceWindowActionId,
+		label:          "Create Mobile App Maintenance Window",
+		targetType:     exteum.MobileAppTargetId,
+		targetLabel:    "mobile app",
+		idAttribute:    "instana.mobile-app.id",
+		labelAttribute: "instana.mobile-app.label",
+		queryKey:       "entity.mobileapp.id",
+	}}
 }
 func (m *CreateMaintenanceWindowAction) NewEmptyState() CreateMaintenanceWindowState {
 	return CreateMaintenanceWindowState{}
@@ -44,18 +138,18 @@ func (m *CreateMaintenanceWindowAction) NewEmptyState() CreateMaintenanceWindowS
 
 func (m *CreateMaintenanceWindowAction) Describe() action_kit_api.ActionDescription {
 	return action_kit_api.ActionDescription{
-		Id:          MaintenanceWindowActionId,
-		Label:       "Create Maintenance Window",
+		Id:          m.target.actionId,
+		Label:       m.target.label,
 		Description: "Start a Maintenance Window for a given duration.",
 		Version:     extbuild.GetSemverVersionStringOrUnknown(),
 		Icon:        new(maintenanceWindowActionIcon),
 		TargetSelection: new(action_kit_api.TargetSelection{
-			TargetType:          extapplications.ApplicationPerspectiveTargetId,
+			TargetType:          m.target.targetType,
 			QuantityRestriction: extutil.Ptr(action_kit_api.QuantityRestrictionAll),
 			SelectionTemplates: new([]action_kit_api.TargetSelectionTemplate{
 				{
-					Label: "application perspective label",
-					Query: "instana.application.label=\"\"",
+					Label: fmt.Sprintf("%s label", m.target.targetLabel),
+					Query: fmt.Sprintf("%s=\"\"", m.target.labelAttribute),
 				},
 			}),
 		}),
@@ -72,20 +166,65 @@ func (m *CreateMaintenanceWindowAction) Describe() action_kit_api.ActionDescript
 				Order:        new(1),
 				Required:     new(true),
 			},
+			{
+				Name:        "query",
+				Label:       "Dynamic Focus Query",
+				Description: new("Optional Dynamic Focus Query, like 'entity.kubernetes.namespace:\"shop\"', to narrow down or replace the scope of the maintenance window."),
+				Type:        action_kit_api.ActionParameterTypeString,
+				Order:       new(2),
+				Required:    new(false),
+			},
+			{
+				Name:        "queryMode",
+				Label:       "Query Mode",
+				Description: new("Whether the Dynamic Focus Query is combined with the scope of the target or replaces it."),
+				Type:        action_kit_api.ActionParameterTypeString,
+				Options: new([]action_kit_api.ParameterOption{
+					action_kit_api.ExplicitParameterOption{Label: "Combine with target scope", Value: queryModeCombine},
+					action_kit_api.ExplicitParameterOption{Label: "Replace target scope", Value: queryModeReplace},
+				}),
+				DefaultValue: new(queryModeCombine),
+				Order:        new(3),
+				Required:     new(true),
+				Advanced:     new(true),
+			},
+			{
+				Name:         "failOnNoEntities",
+				Label:        "Fail if no entities are in scope",
+				Description:  new("Fail the step if the query of the created maintenance window matches no Instana infrastructure entity. Not evaluated for queries on services, endpoints, websites or mobile apps."),
+				Type:         action_kit_api.ActionParameterTypeBoolean,
+				DefaultValue: new("false"),
+				Order:        new(4),
+				Required:     new(false),
+				Advanced:     new(true),
+			},
 		},
+		Status: new(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: new("10s"),
+		}),
 		Stop: new(action_kit_api.MutatingEndpointReference{}),
 	}
 }
 
-func (m *CreateMaintenanceWindowAction) Prepare(_ context.Context, state *CreateMaintenanceWindowState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
-	applicationPerspectiveIds := request.Target.Attributes["instana.application.id"]
-	if len(applicationPerspectiveIds) == 0 {
-		return nil, extension_kit.ToError("Target is missing the 'instana.application.id' attribute.", nil)
+func (m *CreateMaintenanceWindowAction) Prepare(ctx context.Context, state *CreateMaintenanceWindowState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	ids := request.Target.Attributes[m.target.idAttribute]
+	if len(ids) == 0 {
+		return nil, extension_kit.ToError(fmt.Sprintf("Target is missing the '%s' attribute.", m.target.idAttribute), nil)
 	}
-	state.ApplicationPerspectiveId = applicationPerspectiveIds[0]
+	scope := fmt.Sprintf("%s:\"%s\"", m.target.queryKey, ids[0])
+	query, err := PrepareQuery(ctx, scope, extutil.ToString(request.Config["query"]), extutil.ToString(request.Config["queryMode"]), &config.Config)
+	if err != nil {
+		return nil, err
+	}
+	state.Query = query
 	state.ExperimentKey = request.ExecutionContext.ExperimentKey
 	state.ExecutionId = request.ExecutionContext.ExecutionId
+	state.ActionExecutionId = request.ExecutionId.String()
+	if labels := request.Target.Attributes[m.target.labelAttribute]; len(labels) > 0 {
+		state.TargetLabel = labels[0]
+	}
 	state.DurationInMillis = extutil.ToInt64(request.Config["duration"])
+	state.FailOnNoEntities = extutil.ToBool(request.Config["failOnNoEntities"])
 	return nil, nil
 }
 
@@ -93,13 +232,47 @@ func (m *CreateMaintenanceWindowAction) Start(ctx context.Context, state *Create
 	return CreateMaintenanceWindow(ctx, state, &config.Config)
 }
 
+func (m *CreateMaintenanceWindowAction) Status(ctx context.Context, state *CreateMaintenanceWindowState) (*action_kit_api.StatusResult, error) {
+	return ExtendMaintenanceWindow(ctx, state, &config.Config, time.Now())
+}
+
 func (m *CreateMaintenanceWindowAction) Stop(ctx context.Context, state *CreateMaintenanceWindowState) (*action_kit_api.StopResult, error) {
 	return DeleteMaintenanceWindow(ctx, state, &config.Config)
 }
 
+type ValidateQueryApi interface {
+	ValidateQuery(ctx context.Context, query string) error
+}
+
+// PrepareQuery combines the scope of the target with the custom Dynamic Focus Query according to the mode and
+// validates the resulting query against Instana, so that a typo doesn't silently create an ineffective maintenance window.
+func PrepareQuery(ctx context.Context, scope string, customQuery string, mode string, api ValidateQueryApi) (string, error) {
+	customQuery = strings.TrimSpace(customQuery)
+	if customQuery == "" {
+		return scope, nil
+	}
+
+	var query string
+	switch mode {
+	case queryModeReplace:
+		query = customQuery
+	case queryModeCombine, "":
+		query = fmt.Sprintf("(%s) AND (%s)", scope, customQuery)
+	default:
+		return "", extension_kit.ToError(fmt.Sprintf("Unknown query mode '%s'.", mode), nil)
+	}
+
+	if err := api.ValidateQuery(ctx, query); err != nil {
+		return "", extension_kit.ToError(fmt.Sprintf("Invalid Dynamic Focus Query '%s'.", query), err)
+	}
+	return query, nil
+}
+
 type MaintenanceWindowApi interface {
 	CreateMaintenanceWindow(ctx context.Context, maintenanceWindow types.CreateMaintenanceWindowRequest) (*string, *http.Response, error)
 	DeleteMaintenanceWindow(ctx context.Context, maintenanceWindowId string) (*http.Response, error)
+	GetMaintenanceWindow(ctx context.Context, maintenanceWindowId string) (*types.MaintenanceWindow, error)
+	CountSnapshots(ctx context.Context, query string) (int, error)
 }
 
 func CreateMaintenanceWindow(ctx context.Context, state *CreateMaintenanceWindowState, api MaintenanceWindowApi) (*action_kit_api.StartResult, error) {
@@ -107,25 +280,29 @@ func CreateMaintenanceWindow(ctx context.Context, state *CreateMaintenanceWindow
 	if state.ExperimentKey != nil && state.ExecutionId != nil {
 		name = fmt.Sprintf("Steadybit %s - %d", *state.ExperimentKey, *state.ExecutionId)
 	}
+	if state.TargetLabel != "" {
+		name = fmt.Sprintf("%s (%s)", name, state.TargetLabel)
+	}
 
 	id := fmt.Sprintf("%d", time.Now().UnixMilli())
 	if state.ExperimentKey != nil && state.ExecutionId != nil {
 		id = fmt.Sprintf("%s-%d", *state.ExperimentKey, *state.ExecutionId)
 	}
+	// An experiment run creates one window per selected target, so the id needs to be unique per target.
+	if state.ActionExecutionId != "" {
+		id = fmt.Sprintf("%s-%s", id, state.ActionExecutionId)
+	}
+	id = maintenanceWindowIdPrefix + id
 
-	amount := int64(math.Ceil(float64(state.DurationInMillis) / 1000 / 60))
-
+	start := time.Now()
 	createRequest := types.CreateMaintenanceWindowRequest{
 		Id:    id,
 		Name:  name,
-		Query: fmt.Sprintf("entity.application.id:\"%s\"", state.ApplicationPerspectiveId),
+		Query: state.Query,
 		Scheduling: types.Schedule{
-			Duration: types.Duration{
-				Amount: amount,
-				Unit:   "MINUTES",
-			},
-			Start: time.Now().UnixMilli(),
-			Type:  "ONE_TIME",
+			Duration: toScheduleDuration(time.Duration(state.DurationInMillis) * time.Millisecond),
+			Start:    start.UnixMilli(),
+			Type:     "ONE_TIME",
 		},
 	}
 
@@ -135,20 +312,102 @@ func CreateMaintenanceWindow(ctx context.Context, state *CreateMaintenanceWindow
 	}
 
 	state.MaintenanceWindowId = windowId
+	state.Request = &createRequest
+	activeMaintenanceWindows.Store(*windowId, true)
+
+	messages := action_kit_api.Messages{
+		action_kit_api.Message{Level: extutil.Ptr(action_kit_api.Info), Message: fmt.Sprintf("Maintenance window created. (id %s)", *state.MaintenanceWindowId)},
+	}
+	verification, err := verifyMaintenanceWindow(ctx, state, api)
+	if err != nil {
+		if _, deleteErr := DeleteMaintenanceWindow(ctx, state, api); deleteErr != nil {
+			log.Warn().Err(deleteErr).Str("id", *state.MaintenanceWindowId).Msg("Failed to delete ineffective maintenance window.")
+		}
+		state.MaintenanceWindowId = nil
+		return nil, err
+	}
+	messages = append(messages, verification)
 
 	return &action_kit_api.StartResult{
+		Messages: &messages,
+	}, nil
+}
+
+// verifyMaintenanceWindow reads back the created window and counts the infrastructure entities its query matches.
+// Problems reading it back are only reported, but an empty scope fails the step if requested. Queries on entities
+// which aren't infrastructure entities, like websites, aren't counted.
+func verifyMaintenanceWindow(ctx context.Context, state *CreateMaintenanceWindowState, api MaintenanceWindowApi) (action_kit_api.Message, error) {
+	window, err := api.GetMaintenanceWindow(ctx, *state.MaintenanceWindowId)
+	if err != nil {
+		return action_kit_api.Message{Level: extutil.Ptr(action_kit_api.Warn), Message: fmt.Sprintf("Failed to read back maintenance window (id %s): %s", *state.MaintenanceWindowId, err.Error())}, nil
+	}
+	if slices.ContainsFunc(nonInfrastructureQueryKeys, func(key string) bool { return strings.Contains(window.Query, key) }) {
+		return action_kit_api.Message{Level: extutil.Ptr(action_kit_api.Info), Message: fmt.Sprintf("The maintenance window is active for the query '%s'. Its entities can't be counted.", window.Query)}, nil
+	}
+
+	count, err := api.CountSnapshots(ctx, window.Query)
+	if err != nil {
+		return action_kit_api.Message{Level: extutil.Ptr(action_kit_api.Warn), Message: fmt.Sprintf("Failed to evaluate the scope of maintenance window (id %s): %s", *state.MaintenanceWindowId, err.Error())}, nil
+	}
+	if count == 0 {
+		if state.FailOnNoEntities {
+			return action_kit_api.Message{}, extension_kit.ToError(fmt.Sprintf("The query '%s' of the maintenance window matches no Instana entities.", window.Query), nil)
+		}
+		return action_kit_api.Message{Level: extutil.Ptr(action_kit_api.Warn), Message: fmt.Sprintf("The query '%s' of the maintenance window matches no Instana entities, alerts may still fire.", window.Query)}, nil
+	}
+	return action_kit_api.Message{Level: extutil.Ptr(action_kit_api.Info), Message: fmt.Sprintf("The maintenance window covers %d Instana entities.", count)}, nil
+}
+
+// ExtendMaintenanceWindow moves the end of the maintenance window ahead while the step is still running, e.g. because
+// the experiment is paused, so that the window is only ended by Stop and silencing matches the attack duration.
+func ExtendMaintenanceWindow(ctx context.Context, state *CreateMaintenanceWindowState, api MaintenanceWindowApi, now time.Time) (*action_kit_api.StatusResult, error) {
+	if state.MaintenanceWindowId == nil || state.Request == nil {
+		return &action_kit_api.StatusResult{Completed: false}, nil
+	}
+
+	start := time.UnixMilli(state.Request.Scheduling.Start)
+	end := start.Add(scheduledDuration(state.Request.Scheduling.Duration))
+	if end.Sub(now) > extensionThreshold {
+		return &action_kit_api.StatusResult{Completed: false}, nil
+	}
+
+	extended := *state.Request
+	extended.Id = *state.MaintenanceWindowId
+	extended.Scheduling.Duration = toScheduleDuration(now.Add(extensionLead).Sub(start))
+	if _, _, err := api.CreateMaintenanceWindow(ctx, extended); err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to extend maintenance window (id %s).", *state.MaintenanceWindowId), err)
+	}
+	state.Request = &extended
+
+	newEnd := start.Add(scheduledDuration(extended.Scheduling.Duration))
+	return &action_kit_api.StatusResult{
+		Completed: false,
 		Messages: &action_kit_api.Messages{
-			action_kit_api.Message{Level: extutil.Ptr(action_kit_api.Info), Message: fmt.Sprintf("Maintenance window created. (id %s)", *state.MaintenanceWindowId)},
+			action_kit_api.Message{Level: extutil.Ptr(action_kit_api.Info), Message: fmt.Sprintf("Maintenance window extended until %s. (id %s)", newEnd.Format(time.RFC3339), *state.MaintenanceWindowId)},
 		},
 	}, nil
 }
 
+// toScheduleDuration converts the duration into the scheduling of Instana, which accepts whole minutes at the finest.
+// The window is deleted by Stop, so the rounding only affects a window left behind by a lost Stop.
+func toScheduleDuration(duration time.Duration) types.Duration {
+	return types.Duration{
+		Amount: max(1, int64(math.Ceil(duration.Minutes()))),
+		Unit:   "MINUTES",
+	}
+}
+
 func DeleteMaintenanceWindow(ctx context.Context, state *CreateMaintenanceWindowState, api MaintenanceWindowApi) (*action_kit_api.StopResult, error) {
 	if state.MaintenanceWindowId == nil {
 		return nil, nil
 	}
 
 	resp, err := api.DeleteMaintenanceWindow(ctx, *state.MaintenanceWindowId)
+	activeMaintenanceWindows.Delete(*state.MaintenanceWindowId)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		log.Info().Str("id", *state.MaintenanceWindowId).Msg("Maintenance window was already deleted.")
+		return nil, nil
+	}
 	if err != nil {
 		return nil, extension_kit.ToError(fmt.Sprintf("Failed to delete maintenace window (id %s). Full response: %v", *state.MaintenanceWindowId, resp), err)
 	}
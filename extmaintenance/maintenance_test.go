@@ -0,0 +1,226 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extmaintenance
+
+import (
+	"context"
+	"errors"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type instanaApiMock struct {
+	mock.Mock
+}
+
+func (m *instanaApiMock) ValidateQuery(ctx context.Context, query string) error {
+	args := m.Called(ctx, query)
+	return args.Error(0)
+}
+
+func (m *instanaApiMock) CreateMaintenanceWindow(ctx context.Context, maintenanceWindow types.CreateMaintenanceWindowRequest) (*string, *http.Response, error) {
+	args := m.Called(ctx, maintenanceWindow)
+	return new(maintenanceWindow.Id), nil, args.Error(0)
+}
+
+func (m *instanaApiMock) DeleteMaintenanceWindow(ctx context.Context, maintenanceWindowId string) (*http.Response, error) {
+	args := m.Called(ctx, maintenanceWindowId)
+	return nil, args.Error(0)
+}
+
+func (m *instanaApiMock) GetMaintenanceWindow(ctx context.Context, maintenanceWindowId string) (*types.MaintenanceWindow, error) {
+	args := m.Called(ctx, maintenanceWindowId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.MaintenanceWindow), args.Error(1)
+}
+
+func (m *instanaApiMock) CountSnapshots(ctx context.Context, query string) (int, error) {
+	args := m.Called(ctx, query)
+	return args.Int(0), args.Error(1)
+}
+
+func mockReadBack(mockedApi *instanaApiMock, count int) {
+	mockedApi.On("GetMaintenanceWindow", mock.Anything, mock.Anything).Return(&types.MaintenanceWindow{Query: `entity.application.id:"app1"`}, nil)
+	mockedApi.On("CountSnapshots", mock.Anything, mock.Anything).Return(count, nil)
+}
+
+func TestMaintenanceWindowsOfOneRunHaveUniqueIds(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("CreateMaintenanceWindow", mock.Anything, mock.Anything).Return(nil)
+	mockedApi.On("DeleteMaintenanceWindow", mock.Anything, mock.Anything).Return(nil)
+	mockReadBack(mockedApi, 3)
+	states := []*CreateMaintenanceWindowState{
+		{Query: `entity.application.id:"app1"`, DurationInMillis: 60000, ExperimentKey: new("EXP-1"), ExecutionId: new(42), ActionExecutionId: "a1", TargetLabel: "shop"},
+		{Query: `entity.application.id:"app2"`, DurationInMillis: 60000, ExperimentKey: new("EXP-1"), ExecutionId: new(42), ActionExecutionId: "a2", TargetLabel: "billing"},
+	}
+
+	// When
+	for _, state := range states {
+		_, err := CreateMaintenanceWindow(context.Background(), state, mockedApi)
+		require.NoError(t, err)
+	}
+	for _, state := range states {
+		_, err := DeleteMaintenanceWindow(context.Background(), state, mockedApi)
+		require.NoError(t, err)
+	}
+
+	// Then
+	require.Equal(t, "steadybit-EXP-1-42-a1", *states[0].MaintenanceWindowId)
+	require.Equal(t, "steadybit-EXP-1-42-a2", *states[1].MaintenanceWindowId)
+	mockedApi.AssertCalled(t, "CreateMaintenanceWindow", mock.Anything, mock.MatchedBy(func(request types.CreateMaintenanceWindowRequest) bool {
+		return request.Name == "Steadybit EXP-1 - 42 (billing)" && request.Query == `entity.application.id:"app2"`
+	}))
+	mockedApi.AssertCalled(t, "DeleteMaintenanceWindow", mock.Anything, "steadybit-EXP-1-42-a1")
+	mockedApi.AssertCalled(t, "DeleteMaintenanceWindow", mock.Anything, "steadybit-EXP-1-42-a2")
+}
+
+func TestPrepareQueryWithoutCustomQueryUsesScope(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+
+	// When
+	query, err := PrepareQuery(context.Background(), `entity.application.id:"app1"`, " ", queryModeReplace, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, `entity.application.id:"app1"`, query)
+	mockedApi.AssertNotCalled(t, "ValidateQuery", mock.Anything, mock.Anything)
+}
+
+func TestPrepareQueryCombinesScopeAndCustomQuery(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("ValidateQuery", mock.Anything, `(entity.application.id:"app1") AND (entity.kubernetes.namespace:"shop")`).Return(nil)
+
+	// When
+	query, err := PrepareQuery(context.Background(), `entity.application.id:"app1"`, `entity.kubernetes.namespace:"shop"`, queryModeCombine, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, `(entity.application.id:"app1") AND (entity.kubernetes.namespace:"shop")`, query)
+}
+
+func TestPrepareQueryReplacesScope(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("ValidateQuery", mock.Anything, `entity.service.name:"cart"`).Return(nil)
+
+	// When
+	query, err := PrepareQuery(context.Background(), `entity.application.id:"app1"`, `entity.service.name:"cart"`, queryModeReplace, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, `entity.service.name:"cart"`, query)
+}
+
+func TestPrepareQueryFailsForRejectedQuery(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("ValidateQuery", mock.Anything, mock.Anything).Return(errors.New("Unknown tag entity.foo"))
+
+	// When
+	_, err := PrepareQuery(context.Background(), `entity.application.id:"app1"`, `entity.foo:"bar"`, queryModeCombine, mockedApi)
+
+	// Then
+	require.ErrorContains(t, err, "Invalid Dynamic Focus Query")
+}
+
+func TestMaintenanceWindowIsExtendedBeforeItEnds(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("CreateMaintenanceWindow", mock.Anything, mock.Anything).Return(nil)
+	mockReadBack(mockedApi, 3)
+	state := &CreateMaintenanceWindowState{Query: `entity.application.id:"app1"`, DurationInMillis: 90000}
+	_, err := CreateMaintenanceWindow(context.Background(), state, mockedApi)
+	require.NoError(t, err)
+	require.Equal(t, types.Duration{Amount: 2, Unit: "MINUTES"}, state.Request.Scheduling.Duration)
+	start := time.UnixMilli(state.Request.Scheduling.Start)
+
+	// When
+	result, err := ExtendMaintenanceWindow(context.Background(), state, mockedApi, start.Add(30*time.Second))
+
+	// Then
+	require.NoError(t, err)
+	require.False(t, result.Completed)
+	mockedApi.AssertNumberOfCalls(t, "CreateMaintenanceWindow", 1)
+
+	// When
+	result, err = ExtendMaintenanceWindow(context.Background(), state, mockedApi, start.Add(90*time.Second))
+
+	// Then
+	require.NoError(t, err)
+	require.False(t, result.Completed)
+	require.NotNil(t, result.Messages)
+	mockedApi.AssertNumberOfCalls(t, "CreateMaintenanceWindow", 2)
+	require.Equal(t, types.Duration{Amount: 5, Unit: "MINUTES"}, state.Request.Scheduling.Duration)
+	require.Equal(t, *state.MaintenanceWindowId, state.Request.Id)
+	require.Equal(t, start.UnixMilli(), state.Request.Scheduling.Start)
+}
+
+func TestMaintenanceWindowReportsCoveredEntities(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("CreateMaintenanceWindow", mock.Anything, mock.Anything).Return(nil)
+	mockReadBack(mockedApi, 7)
+	state := &CreateMaintenanceWindowState{Query: `entity.application.id:"app1"`, DurationInMillis: 60000}
+
+	// When
+	result, err := CreateMaintenanceWindow(context.Background(), state, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, *result.Messages, 2)
+	require.Equal(t, "The maintenance window covers 7 Instana entities.", (*result.Messages)[1].Message)
+}
+
+func TestMaintenanceWindowWithoutEntitiesWarnsOrFails(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("CreateMaintenanceWindow", mock.Anything, mock.Anything).Return(nil)
+	mockedApi.On("DeleteMaintenanceWindow", mock.Anything, mock.Anything).Return(nil)
+	mockReadBack(mockedApi, 0)
+
+	// When
+	result, err := CreateMaintenanceWindow(context.Background(), &CreateMaintenanceWindowState{Query: "q", DurationInMillis: 60000}, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, action_kit_api.Warn, *(*result.Messages)[1].Level)
+	mockedApi.AssertNotCalled(t, "DeleteMaintenanceWindow", mock.Anything, mock.Anything)
+
+	// When
+	state := &CreateMaintenanceWindowState{Query: "q", DurationInMillis: 60000, FailOnNoEntities: true}
+	_, err = CreateMaintenanceWindow(context.Background(), state, mockedApi)
+
+	// Then
+	require.ErrorContains(t, err, "matches no Instana entities")
+	require.Nil(t, state.MaintenanceWindowId)
+	mockedApi.AssertNumberOfCalls(t, "DeleteMaintenanceWindow", 1)
+}
+
+func TestWebsiteMaintenanceWindowIsNotCountedAsInfrastructure(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("CreateMaintenanceWindow", mock.Anything, mock.Anything).Return(nil)
+	mockedApi.On("GetMaintenanceWindow", mock.Anything, mock.Anything).Return(&types.MaintenanceWindow{Query: `entity.website.id:"web1"`}, nil)
+	state := &CreateMaintenanceWindowState{Query: `entity.website.id:"web1"`, DurationInMillis: 60000, FailOnNoEntities: true}
+
+	// When
+	result, err := CreateMaintenanceWindow(context.Background(), state, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, state.MaintenanceWindowId)
+	require.Equal(t, action_kit_api.Info, *(*result.Messages)[1].Level)
+	mockedApi.AssertNotCalled(t, "CountSnapshots", mock.Anything, mock.Anything)
+	mockedApi.AssertNotCalled(t, "DeleteMaintenanceWindow", mock.Anything, mock.Anything)
+}
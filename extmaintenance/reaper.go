@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extmaintenance
+
+import (
+	"context"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-instana/config"
+	"github.com/steadybit/extension-instana/types"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maintenanceWindowIdPrefix marks the maintenance windows created by the extension.
+	maintenanceWindowIdPrefix = "steadybit-"
+	// maintenanceWindowGracePeriod is the time after the end of its schedule before a maintenance window is deleted,
+	// leaving the experiment execution time to delete it itself.
+	maintenanceWindowGracePeriod = 15 * time.Minute
+)
+
+// activeMaintenanceWindows holds the ids of the maintenance windows created by this process and not yet deleted.
+var activeMaintenanceWindows sync.Map
+
+type MaintenanceWindowReaperApi interface {
+	GetMaintenanceWindows(ctx context.Context) ([]types.MaintenanceWindow, error)
+	DeleteMaintenanceWindow(ctx context.Context, maintenanceWindowId string) (*http.Response, error)
+}
+
+// StartMaintenanceWindowReaper deletes orphaned maintenance windows right away and then in the configured interval.
+func StartMaintenanceWindowReaper(ctx context.Context) {
+	interval := config.Config.MaintenanceWindowReaperInterval
+	if interval <= 0 {
+		log.Info().Msg("Maintenance window reaper is disabled.")
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			reapMaintenanceWindows(ctx, &config.Config, time.Now())
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// reapMaintenanceWindows deletes the maintenance windows created by the extension which are not active in this process
+// and whose schedule ended more than the grace period ago. Windows of experiment executions still running, maybe started
+// by another replica or a previous process of the extension, are kept until their schedule ended.
+func reapMaintenanceWindows(ctx context.Context, api MaintenanceWindowReaperApi, now time.Time) {
+	windows, err := api.GetMaintenanceWindows(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to list maintenance windows, skipping the cleanup.")
+		return
+	}
+
+	for _, window := range windows {
+		if !strings.HasPrefix(window.Id, maintenanceWindowIdPrefix) {
+			continue
+		}
+		if _, active := activeMaintenanceWindows.Load(window.Id); active {
+			continue
+		}
+		end := time.UnixMilli(window.Scheduling.Start).Add(scheduledDuration(window.Scheduling.Duration))
+		if !now.After(end.Add(maintenanceWindowGracePeriod)) {
+			continue
+		}
+
+		log.Info().Str("id", window.Id).Str("name", window.Name).Msg("Deleting orphaned maintenance window.")
+		if _, err := api.DeleteMaintenanceWindow(ctx, window.Id); err != nil {
+			log.Warn().Err(err).Str("id", window.Id).Msg("Failed to delete orphaned maintenance window.")
+		}
+	}
+}
+
+func scheduledDuration(duration types.Duration) time.Duration {
+	unit := time.Minute
+	switch duration.Unit {
+	case "SECONDS":
+		unit = time.Second
+	case "HOURS":
+		unit = time.Hour
+	case "DAYS":
+		unit = 24 * time.Hour
+	}
+	return time.Duration(duration.Amount) * unit
+}
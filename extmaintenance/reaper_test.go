@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extmaintenance
+
+import (
+	"context"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func (m *instanaApiMock) GetMaintenanceWindows(ctx context.Context) ([]types.MaintenanceWindow, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]types.MaintenanceWindow), args.Error(1)
+}
+
+func TestReaperDeletesEndedSteadybitWindowsOnly(t *testing.T) {
+	// Given
+	now := time.Now()
+	activeMaintenanceWindows.Store("steadybit-active", true)
+	defer activeMaintenanceWindows.Delete("steadybit-active")
+
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetMaintenanceWindows", mock.Anything).Return([]types.MaintenanceWindow{
+		{Id: "manual", Scheduling: types.Schedule{Start: now.Add(-24 * time.Hour).UnixMilli()}},
+		{Id: "steadybit-active", Scheduling: types.Schedule{Start: now.Add(-24 * time.Hour).UnixMilli()}},
+		{Id: "steadybit-running-of-other-process", Scheduling: types.Schedule{Start: now.Add(-time.Hour).UnixMilli(), Duration: types.Duration{Amount: 2, Unit: "HOURS"}}},
+		{Id: "steadybit-ended-within-grace-period", Scheduling: types.Schedule{Start: now.Add(-40 * time.Minute).UnixMilli(), Duration: types.Duration{Amount: 30, Unit: "MINUTES"}}},
+		{Id: "steadybit-expired", Scheduling: types.Schedule{Start: now.Add(-time.Hour).UnixMilli(), Duration: types.Duration{Amount: 30, Unit: "MINUTES"}}},
+	}, nil)
+	mockedApi.On("DeleteMaintenanceWindow", mock.Anything, mock.Anything).Return(nil)
+
+	// When
+	reapMaintenanceWindows(context.Background(), mockedApi, now)
+
+	// Then
+	mockedApi.AssertNumberOfCalls(t, "DeleteMaintenanceWindow", 1)
+	mockedApi.AssertCalled(t, "DeleteMaintenanceWindow", mock.Anything, "steadybit-expired")
+}
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extmetrics
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-instana/config"
+	"github.com/steadybit/extension-instana/extapplications"
+	"github.com/steadybit/extension-instana/extcommon"
+	"github.com/steadybit/extension-instana/types"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"time"
+)
+
+type ApplicationMetricsCheckAction struct{}
+
+// Make sure action implements all required interfaces
+var (
+	_ action_kit_sdk.Action[ApplicationMetricsCheckState]           = (*ApplicationMetricsCheckAction)(nil)
+	_ action_kit_sdk.ActionWithStatus[ApplicationMetricsCheckState] = (*ApplicationMetricsCheckAction)(nil)
+)
+
+type ApplicationMetricsCheckState struct {
+	MetricsCheckState
+}
+
+func NewApplicationMetricsCheckAction() action_kit_sdk.Action[ApplicationMetricsCheckState] {
+	return &ApplicationMetricsCheckAction{}
+}
+
+func (m *ApplicationMetricsCheckAction) NewEmptyState() ApplicationMetricsCheckState {
+	return ApplicationMetricsCheckState{}
+}
+
+func (m *ApplicationMetricsCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          ApplicationMetricsCheckActionId,
+		Label:       "Application Metrics Check",
+		Description: "Checks the latency, error rate and throughput of an application perspective in Instana.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        new(metricsCheckActionIcon),
+		TargetSelection: new(action_kit_api.TargetSelection{
+			TargetType:          extapplications.ApplicationPerspectiveTargetId,
+			QuantityRestriction: extutil.Ptr(action_kit_api.QuantityRestrictionAll),
+			SelectionTemplates: new([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label: "application perspective label",
+					Query: "instana.application.label=\"\"",
+				},
+			}),
+		}),
+		Technology:  new("Instana"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		Parameters:  metricsCheckParameters(),
+		Widgets: new([]action_kit_api.Widget{
+			metricsWidget("Instana Application Metrics", "instana_application_metrics", []action_kit_api.LineChartWidgetTooltipContent{
+				{From: "application", Title: "Application Perspective"},
+			}),
+		}),
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: new(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: new("5s"),
+		}),
+	}
+}
+
+func (m *ApplicationMetricsCheckAction) Prepare(_ context.Context, state *ApplicationMetricsCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	return nil, prepareMetricsCheck(&state.MetricsCheckState, request)
+}
+
+func (m *ApplicationMetricsCheckAction) Start(ctx context.Context, state *ApplicationMetricsCheckState) (*action_kit_api.StartResult, error) {
+	statusResult, err := ApplicationMetricsCheckStatus(ctx, state, &config.Config)
+	if statusResult == nil {
+		return nil, err
+	}
+	startResult := action_kit_api.StartResult{
+		Artifacts: statusResult.Artifacts,
+		Error:     statusResult.Error,
+		Messages:  statusResult.Messages,
+		Metrics:   statusResult.Metrics,
+	}
+	return &startResult, err
+}
+
+func (m *ApplicationMetricsCheckAction) Status(ctx context.Context, state *ApplicationMetricsCheckState) (*action_kit_api.StatusResult, error) {
+	return ApplicationMetricsCheckStatus(ctx, state, &config.Config)
+}
+
+type ApplicationMetricsApi interface {
+	GetApplicationMetrics(ctx context.Context, request types.MetricsRequest) (*types.MetricsResponse, error)
+}
+
+func ApplicationMetricsCheckStatus(ctx context.Context, state *ApplicationMetricsCheckState, api ApplicationMetricsApi) (*action_kit_api.StatusResult, error) {
+	now := time.Now()
+	query := func(ctx context.Context, timeFrame types.TimeFrame, granularity int) ([]types.MetricsItem, error) {
+		response, err := api.GetApplicationMetrics(ctx, types.MetricsRequest{
+			ApplicationBoundaryScope: "ALL",
+			ApplicationId:            state.ApplicationPerspectiveId,
+			Metrics:                  goldenSignalMetrics(state.LatencyAggregation, granularity),
+			TimeFrame:                timeFrame,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return response.Items, nil
+	}
+	key := func(item types.MetricsItem) (string, bool) {
+		return "", item.Application == nil || item.Application.Id == state.ApplicationPerspectiveId
+	}
+
+	if err := state.ensureBaselines(ctx, query, key); err != nil {
+		return nil, extension_kit.ToError("Failed to get baseline application metrics from Instana.", err)
+	}
+	items, err := query(ctx, toTimeFrame(now, state.Granularity), state.Granularity)
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to get application metrics from Instana.", err)
+	}
+
+	var sample Sample
+	for _, item := range items {
+		if _, ok := key(item); ok {
+			sample = toSample(item.Metrics, state.LatencyAggregation, state.Granularity)
+			break
+		}
+	}
+
+	baseline := state.baseline("")
+	violations := state.Thresholds.Violations(sample, baseline)
+	completed := now.After(state.End)
+	checkError := extcommon.EvaluateCondition(state.ConditionCheckMode, violations, completed, &state.ConditionCheckSuccess)
+
+	metrics := sampleToMetrics("instana_application_metrics", map[string]string{
+		"application": state.ApplicationPerspectiveLabel,
+	}, sample, baseline, violations, now)
+
+	return &action_kit_api.StatusResult{
+		Completed: completed,
+		Error:     checkError,
+		Metrics:   new(metrics),
+	}, nil
+}
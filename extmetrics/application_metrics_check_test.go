@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extmetrics
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type instanaApiMock struct {
+	mock.Mock
+}
+
+func (m *instanaApiMock) GetApplicationMetrics(ctx context.Context, request types.MetricsRequest) (*types.MetricsResponse, error) {
+	args := m.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.MetricsResponse), args.Error(1)
+}
+
+func applicationMetrics(calls float64, erroneousCalls float64, latency float64) *types.MetricsResponse {
+	return &types.MetricsResponse{
+		Items: []types.MetricsItem{
+			{
+				Application: &types.ApplicationPerspective{Id: "app-1", Label: "shop"},
+				Metrics: map[string][][]float64{
+					"calls.sum":          {{1700000000000, calls}},
+					"erroneousCalls.sum": {{1700000000000, erroneousCalls}},
+					"latency.p99":        {{1700000000000, latency}},
+				},
+			},
+		},
+	}
+}
+
+func TestApplicationMetricsWithinThresholds(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetApplicationMetrics", mock.Anything, mock.Anything).Return(applicationMetrics(600, 3, 250), nil)
+	state := ApplicationMetricsCheckState{MetricsCheckState{
+		End:                      time.Now().Add(time.Minute),
+		ApplicationPerspectiveId: "app-1",
+		Granularity:              60,
+		LatencyAggregation:       "P99",
+		ConditionCheckMode:       conditionCheckModeAllTheTime,
+		Thresholds: Thresholds{
+			MaxLatency:    new(800.0),
+			MaxErrorRate:  new(1.0),
+			MinThroughput: new(100.0),
+		},
+	}}
+
+	// When
+	result, err := ApplicationMetricsCheckStatus(context.Background(), &state, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.Nil(t, result.Error)
+	require.False(t, result.Completed)
+	require.Len(t, *result.Metrics, 3)
+	request := mockedApi.Calls[0].Arguments.Get(1).(types.MetricsRequest)
+	require.Equal(t, "app-1", request.ApplicationId)
+	require.Equal(t, int64(60000), request.TimeFrame.WindowSize)
+}
+
+func TestApplicationMetricsViolationFailsAllTheTime(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetApplicationMetrics", mock.Anything, mock.Anything).Return(applicationMetrics(600, 12, 900), nil)
+	state := ApplicationMetricsCheckState{MetricsCheckState{
+		End:                      time.Now().Add(time.Minute),
+		ApplicationPerspectiveId: "app-1",
+		Granularity:              60,
+		LatencyAggregation:       "P99",
+		ConditionCheckMode:       conditionCheckModeAllTheTime,
+		Thresholds: Thresholds{
+			MaxLatency:   new(800.0),
+			MaxErrorRate: new(1.0),
+		},
+	}}
+
+	// When
+	result, err := ApplicationMetricsCheckStatus(context.Background(), &state, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, result.Error)
+	require.Equal(t, action_kit_api.Failed, *result.Error.Status)
+	require.Equal(t, "Thresholds violated: latency 900ms exceeds 800ms, error rate 2.00% exceeds 1.00%.", result.Error.Title)
+}
+
+func TestApplicationMetricsAtLeastOnceSucceedsAfterRecovery(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetApplicationMetrics", mock.Anything, mock.Anything).Return(applicationMetrics(600, 0, 900), nil).Once()
+	mockedApi.On("GetApplicationMetrics", mock.Anything, mock.Anything).Return(applicationMetrics(600, 0, 300), nil).Once()
+	mockedApi.On("GetApplicationMetrics", mock.Anything, mock.Anything).Return(applicationMetrics(600, 0, 900), nil).Once()
+	state := ApplicationMetricsCheckState{MetricsCheckState{
+		End:                      time.Now().Add(time.Minute),
+		ApplicationPerspectiveId: "app-1",
+		Granularity:              60,
+		LatencyAggregation:       "P99",
+		ConditionCheckMode:       conditionCheckModeAtLeastOnce,
+		Thresholds:               Thresholds{MaxLatency: new(800.0)},
+	}}
+
+	// When
+	first, err := ApplicationMetricsCheckStatus(context.Background(), &state, mockedApi)
+	require.NoError(t, err)
+	second, err := ApplicationMetricsCheckStatus(context.Background(), &state, mockedApi)
+	require.NoError(t, err)
+	state.End = time.Now().Add(-time.Second)
+	last, err := ApplicationMetricsCheckStatus(context.Background(), &state, mockedApi)
+	require.NoError(t, err)
+
+	// Then
+	require.Nil(t, first.Error)
+	require.Nil(t, second.Error)
+	require.True(t, last.Completed)
+	require.Nil(t, last.Error)
+}
+
+func TestFractionalThresholdsAreNotTruncated(t *testing.T) {
+	// Given
+	config := map[string]any{
+		"maxErrorRate":  0.5,
+		"maxLatency":    "250.5",
+		"minThroughput": 10,
+	}
+
+	// When
+	thresholds := toThresholds(config)
+	violations := thresholds.Violations(Sample{ErrorRate: new(0.3), Latency: new(250.2), Throughput: 10}, nil)
+
+	// Then
+	require.Equal(t, 0.5, *thresholds.MaxErrorRate)
+	require.Equal(t, 250.5, *thresholds.MaxLatency)
+	require.Equal(t, 10.0, *thresholds.MinThroughput)
+	require.Nil(t, thresholds.MaxErrorRateIncrease)
+	require.Empty(t, violations)
+}
@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extmetrics
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-instana/types"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extutil"
+	"math"
+	"time"
+)
+
+const (
+	baselineModeNone        = "none"
+	baselineModeBeforeStart = "beforeStart"
+	baselineModePreviousDay = "previousDay"
+)
+
+// metricsQuery fetches the golden signals of all checked entities for the given time frame.
+type metricsQuery func(ctx context.Context, timeFrame types.TimeFrame, granularity int) ([]types.MetricsItem, error)
+
+// entityKey identifies the entity a metrics item belongs to. Items which are not relevant for the check return false.
+type entityKey func(item types.MetricsItem) (string, bool)
+
+func baselineParameters(order int) []action_kit_api.ActionParameter {
+	return []action_kit_api.ActionParameter{
+		{
+			Name:        "baselineMode",
+			Label:       "Baseline",
+			Description: new("Compare the metrics to a baseline to check for relative degradation."),
+			Type:        action_kit_api.ActionParameterTypeString,
+			Options: new([]action_kit_api.ParameterOption{
+				action_kit_api.ExplicitParameterOption{Label: "No baseline", Value: baselineModeNone},
+				action_kit_api.ExplicitParameterOption{Label: "Window before the check", Value: baselineModeBeforeStart},
+				action_kit_api.ExplicitParameterOption{Label: "Same window on the previous day", Value: baselineModePreviousDay},
+			}),
+			DefaultValue: new(baselineModeNone),
+			Order:        new(order),
+			Required:     new(true),
+		},
+		{
+			Name:         "baselineWindow",
+			Label:        "Baseline Window",
+			Description:  new("Length of the baseline window before the check. Only used for the baseline 'Window before the check'."),
+			Type:         action_kit_api.ActionParameterTypeDuration,
+			DefaultValue: new("5m"),
+			Order:        new(order + 1),
+			Required:     new(false),
+		},
+		{
+			Name:        "maxLatencyIncrease",
+			Label:       "Max Latency Increase",
+			Description: new("Fail if the latency increases by more than this share of the baseline latency."),
+			Type:        action_kit_api.ActionParameterTypePercentage,
+			Order:       new(order + 2),
+			Required:    new(false),
+		},
+		{
+			Name:        "maxErrorRateIncrease",
+			Label:       "Max Error Rate Increase (percentage points)",
+			Description: new("Fail if the error rate rises by more than this many percentage points above the baseline error rate."),
+			Type:        action_kit_api.ActionParameterTypePercentage,
+			Order:       new(order + 3),
+			Required:    new(false),
+		},
+		{
+			Name:        "maxThroughputDrop",
+			Label:       "Max Throughput Drop",
+			Description: new("Fail if the throughput drops by more than this share of the baseline throughput."),
+			Type:        action_kit_api.ActionParameterTypePercentage,
+			Order:       new(order + 4),
+			Required:    new(false),
+		},
+	}
+}
+
+func prepareBaseline(state *MetricsCheckState, request action_kit_api.PrepareActionRequestBody) error {
+	state.BaselineMode = extutil.ToString(request.Config["baselineMode"])
+	if state.BaselineMode == "" {
+		state.BaselineMode = baselineModeNone
+	}
+	switch state.BaselineMode {
+	case baselineModeNone, baselineModePreviousDay:
+	case baselineModeBeforeStart:
+		state.BaselineWindowInMillis = extutil.ToInt64(request.Config["baselineWindow"])
+		if state.BaselineWindowInMillis < 1000 {
+			return extension_kit.ToError("The baseline window needs to be at least one second.", nil)
+		}
+	default:
+		return extension_kit.ToError(fmt.Sprintf("Unknown baseline mode: '%s'.", state.BaselineMode), nil)
+	}
+	return nil
+}
+
+// baselineTimeFrame returns the window the baseline is sampled from. The whole window is aggregated into a single data point.
+func (state *MetricsCheckState) baselineTimeFrame() (types.TimeFrame, int) {
+	to := state.Start
+	windowSize := time.Duration(state.BaselineWindowInMillis) * time.Millisecond
+	if state.BaselineMode == baselineModePreviousDay {
+		to = state.End.Add(-24 * time.Hour)
+		windowSize = state.End.Sub(state.Start)
+	}
+	granularity := int(math.Max(1, math.Round(windowSize.Seconds())))
+	return types.TimeFrame{
+		To:         to.UnixMilli(),
+		WindowSize: int64(granularity) * 1000,
+	}, granularity
+}
+
+// ensureBaselines samples the baseline once and keeps it in the state for all further polls.
+func (state *MetricsCheckState) ensureBaselines(ctx context.Context, query metricsQuery, key entityKey) error {
+	if state.BaselineMode == "" || state.BaselineMode == baselineModeNone || state.Baselines != nil {
+		return nil
+	}
+	timeFrame, granularity := state.baselineTimeFrame()
+	items, err := query(ctx, timeFrame, granularity)
+	if err != nil {
+		return err
+	}
+	state.Baselines = make(map[string]Sample, len(items))
+	for _, item := range items {
+		if k, ok := key(item); ok {
+			state.Baselines[k] = toSample(item.Metrics, state.LatencyAggregation, granularity)
+		}
+	}
+	log.Debug().Str("mode", state.BaselineMode).Int("entities", len(state.Baselines)).Msg("Sampled baseline.")
+	return nil
+}
+
+func (state *MetricsCheckState) baseline(key string) *Sample {
+	if state.Baselines == nil {
+		return nil
+	}
+	if baseline, ok := state.Baselines[key]; ok {
+		return &baseline
+	}
+	return nil
+}
+
+func (t Thresholds) relativeViolations(sample Sample, baseline Sample) []string {
+	violations := make([]string, 0)
+	if t.MaxLatencyIncrease != nil && sample.Latency != nil && baseline.Latency != nil && *baseline.Latency > 0 {
+		increase := (*sample.Latency / *baseline.Latency - 1) * 100
+		if increase > *t.MaxLatencyIncrease {
+			violations = append(violations, fmt.Sprintf("latency %.0fms is %.0f%% above baseline %.0fms", *sample.Latency, increase, *baseline.Latency))
+		}
+	}
+	if t.MaxErrorRateIncrease != nil && sample.ErrorRate != nil {
+		baselineErrorRate := 0.0
+		if baseline.ErrorRate != nil {
+			baselineErrorRate = *baseline.ErrorRate
+		}
+		if *sample.ErrorRate-baselineErrorRate > *t.MaxErrorRateIncrease {
+			violations = append(violations, fmt.Sprintf("error rate %.2f%% is %.2f points above baseline %.2f%%", *sample.ErrorRate, *sample.ErrorRate-baselineErrorRate, baselineErrorRate))
+		}
+	}
+	if t.MaxThroughputDrop != nil && baseline.Throughput > 0 {
+		drop := (1 - sample.Throughput/baseline.Throughput) * 100
+		if drop > *t.MaxThroughputDrop {
+			violations = append(violations, fmt.Sprintf("throughput %.0f calls/min is %.0f%% below baseline %.0f calls/min", sample.Throughput, drop, baseline.Throughput))
+		}
+	}
+	return violations
+}
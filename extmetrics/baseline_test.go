@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extmetrics
+
+import (
+	"context"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestBaselineIsSampledOnceBeforeStart(t *testing.T) {
+	// Given
+	start := time.Now()
+	mockedApi := new(instanaApiMock)
+	baselineWindow := mock.MatchedBy(func(r types.MetricsRequest) bool {
+		return r.TimeFrame.To == start.UnixMilli() && r.TimeFrame.WindowSize == 300000 && r.Metrics[0].Granularity == 300
+	})
+	pollWindow := mock.MatchedBy(func(r types.MetricsRequest) bool { return r.TimeFrame.WindowSize == 60000 })
+	mockedApi.On("GetApplicationMetrics", mock.Anything, baselineWindow).Return(applicationMetrics(5000, 5, 400), nil).Once()
+	mockedApi.On("GetApplicationMetrics", mock.Anything, pollWindow).Return(applicationMetrics(800, 1, 560), nil)
+	state := ApplicationMetricsCheckState{MetricsCheckState{
+		Start:                    start,
+		End:                      start.Add(time.Minute),
+		ApplicationPerspectiveId: "app-1",
+		Granularity:              60,
+		LatencyAggregation:       "P99",
+		ConditionCheckMode:       conditionCheckModeAllTheTime,
+		BaselineMode:             baselineModeBeforeStart,
+		BaselineWindowInMillis:   300000,
+		Thresholds: Thresholds{
+			MaxLatencyIncrease: new(50.0),
+			MaxThroughputDrop:  new(10.0),
+		},
+	}}
+
+	// When
+	first, err := ApplicationMetricsCheckStatus(context.Background(), &state, mockedApi)
+	require.NoError(t, err)
+	second, err := ApplicationMetricsCheckStatus(context.Background(), &state, mockedApi)
+	require.NoError(t, err)
+
+	// Then
+	require.Equal(t, 1000.0, state.Baselines[""].Throughput)
+	require.Equal(t, 400.0, *state.Baselines[""].Latency)
+	require.NotNil(t, first.Error)
+	require.Equal(t, "Thresholds violated: throughput 800 calls/min is 20% below baseline 1000 calls/min.", first.Error.Title)
+	require.NotNil(t, second.Error)
+	mockedApi.AssertNumberOfCalls(t, "GetApplicationMetrics", 3)
+
+	baselineSeries := 0
+	for _, metric := range *first.Metrics {
+		if metric.Metric["series"] == "baseline" {
+			baselineSeries++
+		}
+	}
+	require.Equal(t, 3, baselineSeries)
+}
+
+func TestBaselineOfPreviousDayUsesSameWindow(t *testing.T) {
+	// Given
+	start := time.Now()
+	state := MetricsCheckState{
+		Start:        start,
+		End:          start.Add(2 * time.Minute),
+		BaselineMode: baselineModePreviousDay,
+	}
+
+	// When
+	timeFrame, granularity := state.baselineTimeFrame()
+
+	// Then
+	require.Equal(t, start.Add(2*time.Minute).Add(-24*time.Hour).UnixMilli(), timeFrame.To)
+	require.Equal(t, int64(120000), timeFrame.WindowSize)
+	require.Equal(t, 120, granularity)
+}
+
+func TestRelativeThresholds(t *testing.T) {
+	thresholds := Thresholds{
+		MaxLatencyIncrease:   new(30.0),
+		MaxErrorRateIncrease: new(1.0),
+		MaxThroughputDrop:    new(10.0),
+	}
+	baseline := Sample{Latency: new(100.0), ErrorRate: new(0.5), Throughput: 1000}
+
+	require.Empty(t, thresholds.Violations(Sample{Latency: new(129.0), ErrorRate: new(1.4), Throughput: 905}, &baseline))
+	require.Equal(t, []string{
+		"latency 140ms is 40% above baseline 100ms",
+		"error rate 2.00% is 1.50 points above baseline 0.50%",
+		"throughput 500 calls/min is 50% below baseline 1000 calls/min",
+	}, thresholds.Violations(Sample{Latency: new(140.0), ErrorRate: new(2.0), Throughput: 500}, &baseline))
+	require.Empty(t, thresholds.Violations(Sample{Latency: new(140.0), ErrorRate: new(2.0), Throughput: 500}, nil))
+}
@@ -0,0 +1,265 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extmetrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-instana/config"
+	"github.com/steadybit/extension-instana/extapplications"
+	"github.com/steadybit/extension-instana/extcommon"
+	"github.com/steadybit/extension-instana/types"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"sort"
+	"time"
+)
+
+type EndpointMetricsCheckAction struct{}
+
+// Make sure action implements all required interfaces
+var (
+	_ action_kit_sdk.Action[EndpointMetricsCheckState]           = (*EndpointMetricsCheckAction)(nil)
+	_ action_kit_sdk.ActionWithStatus[EndpointMetricsCheckState] = (*EndpointMetricsCheckAction)(nil)
+)
+
+type EndpointMetricsCheckState struct {
+	MetricsCheckState
+	ServiceId          string
+	EndpointNameFilter string
+	Summary            map[string]EndpointSummary
+}
+
+// EndpointSummary aggregates the samples of a single endpoint over the whole check.
+type EndpointSummary struct {
+	Endpoint      string
+	Type          string
+	Polls         int
+	ViolatedPolls int
+	MaxLatency    *float64
+	MaxErrorRate  *float64
+	MinThroughput *float64
+}
+
+func NewEndpointMetricsCheckAction() action_kit_sdk.Action[EndpointMetricsCheckState] {
+	return &EndpointMetricsCheckAction{}
+}
+
+func (m *EndpointMetricsCheckAction) NewEmptyState() EndpointMetricsCheckState {
+	return EndpointMetricsCheckState{}
+}
+
+func (m *EndpointMetricsCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          EndpointMetricsCheckActionId,
+		Label:       "Endpoint Metrics Check",
+		Description: "Checks the latency, error rate and throughput of each endpoint of an application perspective in Instana.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        new(metricsCheckActionIcon),
+		TargetSelection: new(action_kit_api.TargetSelection{
+			TargetType:          extapplications.ApplicationPerspectiveTargetId,
+			QuantityRestriction: extutil.Ptr(action_kit_api.QuantityRestrictionAll),
+			SelectionTemplates: new([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label: "application perspective label",
+					Query: "instana.application.label=\"\"",
+				},
+			}),
+		}),
+		Technology:  new("Instana"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		Parameters: metricsCheckParameters(
+			action_kit_api.ActionParameter{
+				Name:        "serviceId",
+				Label:       "Service Id",
+				Description: new("Only check endpoints of the Instana service with this id. Leave empty to check the endpoints of all services."),
+				Type:        action_kit_api.ActionParameterTypeString,
+				Required:    new(false),
+			},
+			action_kit_api.ActionParameter{
+				Name:        "endpointNameFilter",
+				Label:       "Endpoint Name Filter",
+				Description: new("Only check endpoints whose name contains this text, e.g. 'POST /checkout'. Leave empty to check all endpoints."),
+				Type:        action_kit_api.ActionParameterTypeString,
+				Required:    new(false),
+			},
+		),
+		Widgets: new([]action_kit_api.Widget{
+			metricsWidget("Instana Endpoint Metrics", "instana_endpoint_metrics", []action_kit_api.LineChartWidgetTooltipContent{
+				{From: "endpoint", Title: "Endpoint"},
+				{From: "endpointType", Title: "Endpoint Type"},
+				{From: "application", Title: "Application Perspective"},
+			}),
+		}),
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: new(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: new("5s"),
+		}),
+	}
+}
+
+func (m *EndpointMetricsCheckAction) Prepare(_ context.Context, state *EndpointMetricsCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	state.ServiceId = extutil.ToString(request.Config["serviceId"])
+	state.EndpointNameFilter = extutil.ToString(request.Config["endpointNameFilter"])
+	state.Summary = make(map[string]EndpointSummary)
+	return nil, prepareMetricsCheck(&state.MetricsCheckState, request)
+}
+
+func (m *EndpointMetricsCheckAction) Start(ctx context.Context, state *EndpointMetricsCheckState) (*action_kit_api.StartResult, error) {
+	statusResult, err := EndpointMetricsCheckStatus(ctx, state, &config.Config)
+	if statusResult == nil {
+		return nil, err
+	}
+	startResult := action_kit_api.StartResult{
+		Artifacts: statusResult.Artifacts,
+		Error:     statusResult.Error,
+		Messages:  statusResult.Messages,
+		Metrics:   statusResult.Metrics,
+	}
+	return &startResult, err
+}
+
+func (m *EndpointMetricsCheckAction) Status(ctx context.Context, state *EndpointMetricsCheckState) (*action_kit_api.StatusResult, error) {
+	return EndpointMetricsCheckStatus(ctx, state, &config.Config)
+}
+
+type EndpointMetricsApi interface {
+	GetEndpointMetrics(ctx context.Context, request types.MetricsRequest) (*types.MetricsResponse, error)
+}
+
+func EndpointMetricsCheckStatus(ctx context.Context, state *EndpointMetricsCheckState, api EndpointMetricsApi) (*action_kit_api.StatusResult, error) {
+	now := time.Now()
+	query := func(ctx context.Context, timeFrame types.TimeFrame, granularity int) ([]types.MetricsItem, error) {
+		return getAllMetrics(ctx, types.MetricsRequest{
+			ApplicationBoundaryScope: "ALL",
+			ApplicationId:            state.ApplicationPerspectiveId,
+			ServiceId:                state.ServiceId,
+			NameFilter:               state.EndpointNameFilter,
+			Metrics:                  goldenSignalMetrics(state.LatencyAggregation, granularity),
+			TimeFrame:                timeFrame,
+		}, api.GetEndpointMetrics)
+	}
+	key := func(item types.MetricsItem) (string, bool) {
+		if item.Endpoint == nil {
+			return "", false
+		}
+		return item.Endpoint.Id, true
+	}
+
+	if err := state.ensureBaselines(ctx, query, key); err != nil {
+		return nil, extension_kit.ToError("Failed to get baseline endpoint metrics from Instana.", err)
+	}
+	items, err := query(ctx, toTimeFrame(now, state.Granularity), state.Granularity)
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to get endpoint metrics from Instana.", err)
+	}
+	if state.Summary == nil {
+		state.Summary = make(map[string]EndpointSummary)
+	}
+
+	violations := make([]string, 0)
+	metrics := make([]action_kit_api.Metric, 0, len(items)*3)
+	for _, item := range items {
+		if item.Endpoint == nil {
+			continue
+		}
+		sample := toSample(item.Metrics, state.LatencyAggregation, state.Granularity)
+		baseline := state.baseline(item.Endpoint.Id)
+		endpointViolations := state.Thresholds.Violations(sample, baseline)
+		for _, violation := range endpointViolations {
+			violations = append(violations, fmt.Sprintf("%s: %s", item.Endpoint.Label, violation))
+		}
+		state.Summary[item.Endpoint.Id] = summarize(state.Summary[item.Endpoint.Id], *item.Endpoint, sample, len(endpointViolations) > 0)
+		metrics = append(metrics, sampleToMetrics("instana_endpoint_metrics", map[string]string{
+			"endpoint":     item.Endpoint.Label,
+			"endpointType": item.Endpoint.Type,
+			"application":  state.ApplicationPerspectiveLabel,
+		}, sample, baseline, endpointViolations, now)...)
+	}
+
+	completed := now.After(state.End)
+	checkError := extcommon.EvaluateCondition(state.ConditionCheckMode, violations, completed, &state.ConditionCheckSuccess)
+
+	result := action_kit_api.StatusResult{
+		Completed: completed,
+		Error:     checkError,
+		Metrics:   new(metrics),
+	}
+	if completed || checkError != nil {
+		artifact, err := summaryArtifact(state.Summary)
+		if err != nil {
+			return nil, extension_kit.ToError("Failed to create endpoint summary.", err)
+		}
+		result.Artifacts = new([]action_kit_api.Artifact{artifact})
+	}
+	return &result, nil
+}
+
+func summarize(summary EndpointSummary, endpoint types.Endpoint, sample Sample, violated bool) EndpointSummary {
+	summary.Endpoint = endpoint.Label
+	summary.Type = endpoint.Type
+	summary.Polls++
+	if violated {
+		summary.ViolatedPolls++
+	}
+	if sample.Latency != nil && (summary.MaxLatency == nil || *sample.Latency > *summary.MaxLatency) {
+		summary.MaxLatency = new(*sample.Latency)
+	}
+	if sample.ErrorRate != nil && (summary.MaxErrorRate == nil || *sample.ErrorRate > *summary.MaxErrorRate) {
+		summary.MaxErrorRate = new(*sample.ErrorRate)
+	}
+	if summary.MinThroughput == nil || sample.Throughput < *summary.MinThroughput {
+		summary.MinThroughput = new(sample.Throughput)
+	}
+	return summary
+}
+
+func summaryArtifact(summary map[string]EndpointSummary) (action_kit_api.Artifact, error) {
+	endpoints := make([]EndpointSummary, 0, len(summary))
+	for _, endpoint := range summary {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].Endpoint < endpoints[j].Endpoint
+	})
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	_ = writer.Write([]string{"endpoint", "type", "polls", "violated polls", "max latency (ms)", "max error rate (%)", "min throughput (calls/min)"})
+	for _, endpoint := range endpoints {
+		_ = writer.Write([]string{
+			endpoint.Endpoint,
+			endpoint.Type,
+			fmt.Sprintf("%d", endpoint.Polls),
+			fmt.Sprintf("%d", endpoint.ViolatedPolls),
+			formatOptional(endpoint.MaxLatency, "%.0f"),
+			formatOptional(endpoint.MaxErrorRate, "%.2f"),
+			formatOptional(endpoint.MinThroughput, "%.0f"),
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return action_kit_api.Artifact{}, err
+	}
+
+	return action_kit_api.Artifact{
+		Label: "endpoint_metrics_summary.csv",
+		Data:  base64.StdEncoding.EncodeToString(buffer.Bytes()),
+	}, nil
+}
+
+func formatOptional(value *float64, format string) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprintf(format, *value)
+}
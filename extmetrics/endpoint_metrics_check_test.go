@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extmetrics
+
+import (
+	"context"
+	"encoding/base64"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func (m *instanaApiMock) GetEndpointMetrics(ctx context.Context, request types.MetricsRequest) (*types.MetricsResponse, error) {
+	args := m.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.MetricsResponse), args.Error(1)
+}
+
+func endpointMetrics(label string, calls float64, erroneousCalls float64, latency float64) types.MetricsItem {
+	return types.MetricsItem{
+		Endpoint: &types.Endpoint{Id: label, Label: label, Type: "HTTP"},
+		Metrics: map[string][][]float64{
+			"calls.sum":          {{1700000000000, calls}},
+			"erroneousCalls.sum": {{1700000000000, erroneousCalls}},
+			"latency.p99":        {{1700000000000, latency}},
+		},
+	}
+}
+
+func TestEndpointMetricsSummaryArtifactOnCompletion(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetEndpointMetrics", mock.Anything, mock.Anything).Return(&types.MetricsResponse{
+		Items:     []types.MetricsItem{endpointMetrics("POST /checkout", 1000, 2, 300)},
+		TotalHits: 1,
+	}, nil).Once()
+	mockedApi.On("GetEndpointMetrics", mock.Anything, mock.Anything).Return(&types.MetricsResponse{
+		Items:     []types.MetricsItem{endpointMetrics("POST /checkout", 1000, 4, 450)},
+		TotalHits: 1,
+	}, nil).Once()
+	state := EndpointMetricsCheckState{
+		MetricsCheckState: MetricsCheckState{
+			End:                time.Now().Add(time.Minute),
+			Granularity:        60,
+			LatencyAggregation: "P99",
+			ConditionCheckMode: conditionCheckModeAllTheTime,
+			Thresholds:         Thresholds{MaxErrorRate: new(0.5)},
+		},
+		EndpointNameFilter: "checkout",
+	}
+
+	// When
+	first, err := EndpointMetricsCheckStatus(context.Background(), &state, mockedApi)
+	require.NoError(t, err)
+	state.End = time.Now().Add(-time.Second)
+	last, err := EndpointMetricsCheckStatus(context.Background(), &state, mockedApi)
+	require.NoError(t, err)
+
+	// Then
+	require.Nil(t, first.Error)
+	require.Nil(t, first.Artifacts)
+	require.True(t, last.Completed)
+	require.Nil(t, last.Error)
+	require.Len(t, *last.Artifacts, 1)
+	data, err := base64.StdEncoding.DecodeString((*last.Artifacts)[0].Data)
+	require.NoError(t, err)
+	require.Equal(t, "endpoint,type,polls,violated polls,max latency (ms),max error rate (%),min throughput (calls/min)\nPOST /checkout,HTTP,2,0,450,0.40,1000\n", string(data))
+	request := mockedApi.Calls[0].Arguments.Get(1).(types.MetricsRequest)
+	require.Equal(t, "checkout", request.NameFilter)
+}
+
+func TestEndpointMetricsViolationFailsWithSummary(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetEndpointMetrics", mock.Anything, mock.Anything).Return(&types.MetricsResponse{
+		Items: []types.MetricsItem{
+			endpointMetrics("GET /products", 1000, 0, 100),
+			endpointMetrics("POST /checkout", 1000, 10, 300),
+		},
+		TotalHits: 2,
+	}, nil)
+	state := EndpointMetricsCheckState{MetricsCheckState: MetricsCheckState{
+		End:                time.Now().Add(time.Minute),
+		Granularity:        60,
+		LatencyAggregation: "P99",
+		ConditionCheckMode: conditionCheckModeAllTheTime,
+		Thresholds:         Thresholds{MaxErrorRate: new(0.5)},
+	}}
+
+	// When
+	result, err := EndpointMetricsCheckStatus(context.Background(), &state, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, result.Error)
+	require.Equal(t, "Thresholds violated: POST /checkout: error rate 1.00% exceeds 0.50%.", result.Error.Title)
+	require.NotNil(t, result.Artifacts)
+	require.Equal(t, 1, state.Summary["POST /checkout"].ViolatedPolls)
+	require.Equal(t, 0, state.Summary["GET /products"].ViolatedPolls)
+}
@@ -0,0 +1,344 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extmetrics
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-instana/config"
+	"github.com/steadybit/extension-instana/extcommon"
+	"github.com/steadybit/extension-instana/types"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// rollups are the resolutions in seconds supported by the Instana infrastructure metrics API.
+var rollups = []int{1, 5, 60, 300, 3600}
+
+// maxDataPoints is the maximum number of data points Instana returns per metric and entity.
+const maxDataPoints = 600
+
+type InfrastructureMetricsCheckAction struct{}
+
+// Make sure action implements all required interfaces
+var (
+	_ action_kit_sdk.Action[InfrastructureMetricsCheckState]           = (*InfrastructureMetricsCheckAction)(nil)
+	_ action_kit_sdk.ActionWithStatus[InfrastructureMetricsCheckState] = (*InfrastructureMetricsCheckAction)(nil)
+)
+
+type InfrastructureMetricsCheckState struct {
+	Start                 time.Time
+	End                   time.Time
+	Plugin                string
+	Query                 string
+	Metric                string
+	Aggregation           string
+	MaxValue              *float64
+	MinValue              *float64
+	ConditionCheckMode    string
+	ConditionCheckSuccess bool
+	// ValuesReceived is set once any entity returned values for the metric
+	ValuesReceived bool
+}
+
+func NewInfrastructureMetricsCheckAction() action_kit_sdk.Action[InfrastructureMetricsCheckState] {
+	return &InfrastructureMetricsCheckAction{}
+}
+
+func (m *InfrastructureMetricsCheckAction) NewEmptyState() InfrastructureMetricsCheckState {
+	return InfrastructureMetricsCheckState{}
+}
+
+func (m *InfrastructureMetricsCheckAction) Describe() action_kit_api.ActionDescription {
+	widget := metricsWidget("Instana Infrastructure Metrics", "instana_infrastructure_metrics", nil)
+	widget.Tooltip = new(action_kit_api.LineChartWidgetTooltipConfig{
+		MetricValueTitle: new("Value"),
+		AdditionalContent: []action_kit_api.LineChartWidgetTooltipContent{
+			{From: "entity", Title: "Entity"},
+			{From: "aggregation", Title: "Aggregation"},
+		},
+	})
+
+	return action_kit_api.ActionDescription{
+		Id:          InfrastructureMetricsCheckId,
+		Label:       "Infrastructure Metrics Check",
+		Description: "Checks an infrastructure metric of hosts, containers or Kubernetes entities in Instana against thresholds.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        new(metricsCheckActionIcon),
+		Technology:  new("Instana"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  new(""),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: new("30s"),
+				Order:        new(1),
+				Required:     new(true),
+			},
+			{
+				Name:        "plugin",
+				Label:       "Entity Type",
+				Description: new("The Instana plugin of the entities to check, e.g. 'host', 'docker' or 'kubernetesPod'."),
+				Type:        action_kit_api.ActionParameterTypeString,
+				Options: new([]action_kit_api.ParameterOption{
+					action_kit_api.ExplicitParameterOption{Label: "Host", Value: "host"},
+					action_kit_api.ExplicitParameterOption{Label: "Docker container", Value: "docker"},
+					action_kit_api.ExplicitParameterOption{Label: "Kubernetes pod", Value: "kubernetesPod"},
+					action_kit_api.ExplicitParameterOption{Label: "Kubernetes node", Value: "kubernetesNode"},
+					action_kit_api.ExplicitParameterOption{Label: "Kubernetes deployment", Value: "kubernetesDeployment"},
+					action_kit_api.ExplicitParameterOption{Label: "Process", Value: "process"},
+				}),
+				DefaultValue: new("host"),
+				Order:        new(2),
+				Required:     new(true),
+			},
+			{
+				Name:        "query",
+				Label:       "Dynamic Focus Query",
+				Description: new("Restricts the checked entities, e.g. 'entity.kubernetes.namespace:shop'. Leave empty to check all entities of the type."),
+				Type:        action_kit_api.ActionParameterTypeString,
+				Order:       new(3),
+				Required:    new(false),
+			},
+			{
+				Name:         "metric",
+				Label:        "Metric",
+				Description:  new("The Instana metric to check, e.g. 'cpu.used' or 'memory.used'."),
+				Type:         action_kit_api.ActionParameterTypeString,
+				DefaultValue: new("cpu.used"),
+				Order:        new(4),
+				Required:     new(true),
+			},
+			{
+				Name:        "aggregation",
+				Label:       "Aggregation",
+				Description: new("How the values of each entity since the start of the step are aggregated."),
+				Type:        action_kit_api.ActionParameterTypeString,
+				Options: new([]action_kit_api.ParameterOption{
+					action_kit_api.ExplicitParameterOption{Label: "Average", Value: aggregationAvg},
+					action_kit_api.ExplicitParameterOption{Label: "Maximum", Value: aggregationMax},
+					action_kit_api.ExplicitParameterOption{Label: "95th percentile", Value: aggregationP95},
+				}),
+				DefaultValue: new(aggregationAvg),
+				Order:        new(5),
+				Required:     new(true),
+			},
+			{
+				Name:        "maxValue",
+				Label:       "Max Value",
+				Description: new("Fail if the aggregated value of an entity exceeds this value. Leave empty to not check an upper bound."),
+				Type:        action_kit_api.ActionParameterTypeString,
+				Order:       new(6),
+				Required:    new(false),
+			},
+			{
+				Name:        "minValue",
+				Label:       "Min Value",
+				Description: new("Fail if the aggregated value of an entity drops below this value. Leave empty to not check a lower bound."),
+				Type:        action_kit_api.ActionParameterTypeString,
+				Order:       new(7),
+				Required:    new(false),
+			},
+			extcommon.ConditionCheckModeParameter(8),
+		},
+		Widgets: new([]action_kit_api.Widget{widget}),
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: new(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: new("5s"),
+		}),
+	}
+}
+
+func (m *InfrastructureMetricsCheckAction) Prepare(_ context.Context, state *InfrastructureMetricsCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	duration := extutil.ToInt64(request.Config["duration"])
+	state.Start = time.Now()
+	state.End = time.Now().Add(time.Millisecond * time.Duration(duration))
+
+	state.Plugin = extutil.ToString(request.Config["plugin"])
+	if state.Plugin == "" {
+		return nil, extension_kit.ToError("Missing entity type.", nil)
+	}
+	state.Metric = extutil.ToString(request.Config["metric"])
+	if state.Metric == "" {
+		return nil, extension_kit.ToError("Missing metric.", nil)
+	}
+	state.Query = extutil.ToString(request.Config["query"])
+	state.Aggregation = extutil.ToString(request.Config["aggregation"])
+	if state.Aggregation == "" {
+		state.Aggregation = aggregationAvg
+	}
+	if state.Aggregation != aggregationAvg && state.Aggregation != aggregationMax && state.Aggregation != aggregationP95 {
+		return nil, extension_kit.ToError(fmt.Sprintf("Invalid aggregation: '%s'.", state.Aggregation), nil)
+	}
+
+	for _, key := range []string{"maxValue", "minValue"} {
+		if value, ok := request.Config[key].(string); ok && value != "" {
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return nil, extension_kit.ToError(fmt.Sprintf("Invalid number for '%s': '%s'.", key, value), nil)
+			}
+		}
+	}
+	state.MaxValue = extcommon.OptionalFloat(request.Config, "maxValue")
+	state.MinValue = extcommon.OptionalFloat(request.Config, "minValue")
+	if request.Config["conditionCheckMode"] != nil {
+		state.ConditionCheckMode = fmt.Sprintf("%v", request.Config["conditionCheckMode"])
+	}
+	return nil, nil
+}
+
+func (m *InfrastructureMetricsCheckAction) Start(ctx context.Context, state *InfrastructureMetricsCheckState) (*action_kit_api.StartResult, error) {
+	statusResult, err := InfrastructureMetricsCheckStatus(ctx, state, &config.Config)
+	if statusResult == nil {
+		return nil, err
+	}
+	startResult := action_kit_api.StartResult{
+		Artifacts: statusResult.Artifacts,
+		Error:     statusResult.Error,
+		Messages:  statusResult.Messages,
+		Metrics:   statusResult.Metrics,
+	}
+	return &startResult, err
+}
+
+func (m *InfrastructureMetricsCheckAction) Status(ctx context.Context, state *InfrastructureMetricsCheckState) (*action_kit_api.StatusResult, error) {
+	return InfrastructureMetricsCheckStatus(ctx, state, &config.Config)
+}
+
+type InfrastructureMetricsApi interface {
+	GetInfrastructureMetrics(ctx context.Context, request types.InfrastructureMetricsRequest) (*types.InfrastructureMetricsResponse, error)
+}
+
+func InfrastructureMetricsCheckStatus(ctx context.Context, state *InfrastructureMetricsCheckState, api InfrastructureMetricsApi) (*action_kit_api.StatusResult, error) {
+	now := time.Now()
+	windowSize := now.Sub(state.Start).Milliseconds()
+	rollup := toRollup(windowSize)
+	if windowSize < int64(rollup)*1000 {
+		windowSize = int64(rollup) * 1000
+	}
+
+	response, err := api.GetInfrastructureMetrics(ctx, types.InfrastructureMetricsRequest{
+		Plugin:  state.Plugin,
+		Query:   state.Query,
+		Metrics: []string{state.Metric},
+		Rollup:  rollup,
+		TimeFrame: types.TimeFrame{
+			To:         now.UnixMilli(),
+			WindowSize: windowSize,
+		},
+	})
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to get infrastructure metrics from Instana.", err)
+	}
+
+	violations := make([]string, 0)
+	metrics := make([]action_kit_api.Metric, 0, len(response.Items))
+	for _, item := range response.Items {
+		values := make([]float64, 0, len(item.Metrics[state.Metric]))
+		for _, point := range item.Metrics[state.Metric] {
+			if len(point) >= 2 {
+				values = append(values, point[1])
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		label := item.Label
+		if label == "" {
+			label = item.SnapshotId
+		}
+		value := aggregate(values, state.Aggregation)
+		violated := "false"
+		if state.MaxValue != nil && value > *state.MaxValue {
+			violations = append(violations, fmt.Sprintf("%s: %s %s %.2f > %.2f", label, state.Aggregation, state.Metric, value, *state.MaxValue))
+			violated = "true"
+		}
+		if state.MinValue != nil && value < *state.MinValue {
+			violations = append(violations, fmt.Sprintf("%s: %s %s %.2f < %.2f", label, state.Aggregation, state.Metric, value, *state.MinValue))
+			violated = "true"
+		}
+
+		metrics = append(metrics, action_kit_api.Metric{
+			Name: new("instana_infrastructure_metrics"),
+			Metric: map[string]string{
+				"metric":      state.Metric,
+				"aggregation": state.Aggregation,
+				"entity":      label,
+				"snapshotId":  item.SnapshotId,
+				"violated":    violated,
+			},
+			Timestamp: now,
+			Value:     value,
+		})
+	}
+
+	completed := now.After(state.End)
+	result := action_kit_api.StatusResult{
+		Completed: completed,
+		Metrics:   new(metrics),
+	}
+	if len(metrics) > 0 {
+		state.ValuesReceived = true
+		result.Error = extcommon.EvaluateCondition(state.ConditionCheckMode, violations, completed, &state.ConditionCheckSuccess)
+		return &result, nil
+	}
+
+	// A poll without values must neither satisfy nor violate the thresholds, e.g. a typo in the query matches no entity.
+	result.Messages = new([]action_kit_api.Message{{
+		Level:   extutil.Ptr(action_kit_api.Warn),
+		Message: fmt.Sprintf("No Instana %s entity returned values for the metric %s.", state.Plugin, state.Metric),
+	}})
+	if completed && !state.ValuesReceived {
+		result.Error = new(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("No Instana %s entity returned values for the metric %s during the check. Verify the plugin, metric and query.", state.Plugin, state.Metric),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	} else if completed && state.ConditionCheckMode == conditionCheckModeAtLeastOnce && !state.ConditionCheckSuccess {
+		result.Error = extcommon.EvaluateCondition(state.ConditionCheckMode, []string{"no values"}, completed, &state.ConditionCheckSuccess)
+	}
+	return &result, nil
+}
+
+// toRollup returns the finest rollup that keeps the window within the data points returned by Instana.
+func toRollup(windowSize int64) int {
+	for _, rollup := range rollups {
+		if windowSize/(int64(rollup)*1000) <= maxDataPoints {
+			return rollup
+		}
+	}
+	return rollups[len(rollups)-1]
+}
+
+func aggregate(values []float64, aggregation string) float64 {
+	switch aggregation {
+	case aggregationMax:
+		result := values[0]
+		for _, value := range values[1:] {
+			result = math.Max(result, value)
+		}
+		return result
+	case aggregationP95:
+		sorted := append([]float64(nil), values...)
+		sort.Float64s(sorted)
+		index := int(math.Ceil(0.95*float64(len(sorted)))) - 1
+		return sorted[max(index, 0)]
+	default:
+		sum := 0.0
+		for _, value := range values {
+			sum += value
+		}
+		return sum / float64(len(values))
+	}
+}
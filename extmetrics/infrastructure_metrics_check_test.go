@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extmetrics
+
+import (
+	"context"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func (m *instanaApiMock) GetInfrastructureMetrics(ctx context.Context, request types.InfrastructureMetricsRequest) (*types.InfrastructureMetricsResponse, error) {
+	args := m.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.InfrastructureMetricsResponse), args.Error(1)
+}
+
+func infrastructureMetrics(label string, values ...float64) types.InfrastructureMetricsItem {
+	points := make([][]float64, 0, len(values))
+	for i, value := range values {
+		points = append(points, []float64{float64(1700000000000 + i*1000), value})
+	}
+	return types.InfrastructureMetricsItem{
+		SnapshotId: label + "-snapshot",
+		Plugin:     "host",
+		Label:      label,
+		Metrics:    map[string][][]float64{"cpu.used": points},
+	}
+}
+
+func TestInfrastructureMetricsReportsViolatingEntity(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetInfrastructureMetrics", mock.Anything, mock.Anything).Return(&types.InfrastructureMetricsResponse{
+		Items: []types.InfrastructureMetricsItem{
+			infrastructureMetrics("host-a", 0.2, 0.3, 0.4),
+			infrastructureMetrics("host-b", 0.5, 0.9, 1.0),
+		},
+	}, nil)
+	state := InfrastructureMetricsCheckState{
+		Start:              time.Now().Add(-time.Minute),
+		End:                time.Now().Add(time.Minute),
+		Plugin:             "host",
+		Query:              "entity.zone:eu",
+		Metric:             "cpu.used",
+		Aggregation:        aggregationMax,
+		MaxValue:           new(0.8),
+		ConditionCheckMode: conditionCheckModeAllTheTime,
+	}
+
+	// When
+	result, err := InfrastructureMetricsCheckStatus(context.Background(), &state, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, result.Error)
+	require.Equal(t, "Thresholds violated: host-b: max cpu.used 1.00 > 0.80.", result.Error.Title)
+	require.Len(t, *result.Metrics, 2)
+	for _, metric := range *result.Metrics {
+		require.Equal(t, metric.Metric["entity"] == "host-b", metric.Metric["violated"] == "true")
+	}
+	request := mockedApi.Calls[0].Arguments.Get(1).(types.InfrastructureMetricsRequest)
+	require.Equal(t, "host", request.Plugin)
+	require.Equal(t, "entity.zone:eu", request.Query)
+	require.Equal(t, []string{"cpu.used"}, request.Metrics)
+	require.Equal(t, 1, request.Rollup)
+}
+
+func TestInfrastructureMetricsAtLeastOnce(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetInfrastructureMetrics", mock.Anything, mock.Anything).Return(&types.InfrastructureMetricsResponse{
+		Items: []types.InfrastructureMetricsItem{infrastructureMetrics("host-a", 0.1, 0.2, 0.9)},
+	}, nil)
+	state := InfrastructureMetricsCheckState{
+		Start:              time.Now().Add(-time.Minute),
+		End:                time.Now().Add(-time.Second),
+		Plugin:             "host",
+		Metric:             "cpu.used",
+		Aggregation:        aggregationAvg,
+		MinValue:           new(0.5),
+		ConditionCheckMode: conditionCheckModeAtLeastOnce,
+	}
+
+	// When
+	result, err := InfrastructureMetricsCheckStatus(context.Background(), &state, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.True(t, result.Completed)
+	require.NotNil(t, result.Error)
+	require.Equal(t, "Thresholds were never met during the check.", result.Error.Title)
+}
+
+func TestAggregate(t *testing.T) {
+	values := []float64{5, 1, 4, 2, 3, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}
+	require.Equal(t, 10.5, aggregate(values, aggregationAvg))
+	require.Equal(t, 20.0, aggregate(values, aggregationMax))
+	require.Equal(t, 19.0, aggregate(values, aggregationP95))
+}
+
+func TestToRollup(t *testing.T) {
+	require.Equal(t, 1, toRollup(30_000))
+	require.Equal(t, 1, toRollup(600_000))
+	require.Equal(t, 5, toRollup(601_000))
+	require.Equal(t, 60, toRollup(3_600_000))
+}
+
+func TestInfrastructureMetricsWithoutValuesFails(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetInfrastructureMetrics", mock.Anything, mock.Anything).Return(&types.InfrastructureMetricsResponse{
+		Items: []types.InfrastructureMetricsItem{},
+	}, nil)
+	state := InfrastructureMetricsCheckState{
+		Start:              time.Now().Add(-time.Minute),
+		End:                time.Now().Add(time.Minute),
+		Plugin:             "host",
+		Query:              "entity.zone:ue",
+		Metric:             "cpu.used",
+		Aggregation:        aggregationAvg,
+		MaxValue:           new(0.8),
+		ConditionCheckMode: conditionCheckModeAllTheTime,
+	}
+
+	// When
+	running, runningErr := InfrastructureMetricsCheckStatus(context.Background(), &state, mockedApi)
+	state.End = time.Now().Add(-time.Second)
+	completed, completedErr := InfrastructureMetricsCheckStatus(context.Background(), &state, mockedApi)
+
+	// Then
+	require.NoError(t, runningErr)
+	require.Nil(t, running.Error)
+	require.Equal(t, action_kit_api.Warn, *(*running.Messages)[0].Level)
+	require.NoError(t, completedErr)
+	require.True(t, completed.Completed)
+	require.Equal(t, "No Instana host entity returned values for the metric cpu.used during the check. Verify the plugin, metric and query.", completed.Error.Title)
+}
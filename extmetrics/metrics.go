@@ -0,0 +1,333 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extmetrics
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-instana/extcommon"
+	"github.com/steadybit/extension-instana/types"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extutil"
+	"strings"
+	"time"
+)
+
+// MetricsCheckState is shared by all checks evaluating the golden signals of an application perspective.
+type MetricsCheckState struct {
+	Start                       time.Time
+	End                         time.Time
+	ApplicationPerspectiveId    string
+	ApplicationPerspectiveLabel string
+	Granularity                 int
+	LatencyAggregation          string
+	Thresholds                  Thresholds
+	ConditionCheckMode          string
+	ConditionCheckSuccess       bool
+	BaselineMode                string
+	BaselineWindowInMillis      int64
+	// Baselines are the samples of the baseline window, keyed by the entity they belong to.
+	Baselines map[string]Sample
+}
+
+// Thresholds are the limits the golden signals have to stay within. Unset limits are not checked.
+type Thresholds struct {
+	MaxLatency    *float64
+	MaxErrorRate  *float64
+	MinThroughput *float64
+	// MaxLatencyIncrease in percent of the baseline latency
+	MaxLatencyIncrease *float64
+	// MaxErrorRateIncrease in percentage points above the baseline error rate
+	MaxErrorRateIncrease *float64
+	// MaxThroughputDrop in percent of the baseline throughput
+	MaxThroughputDrop *float64
+}
+
+// Sample is the latest observation of the golden signals of a single entity.
+type Sample struct {
+	// Latency in milliseconds, nil if there were no calls
+	Latency *float64
+	// ErrorRate in percent, nil if there were no calls
+	ErrorRate *float64
+	// Throughput in calls per minute
+	Throughput float64
+}
+
+func prepareMetricsCheck(state *MetricsCheckState, request action_kit_api.PrepareActionRequestBody) error {
+	applicationPerspectiveIds := request.Target.Attributes["instana.application.id"]
+	if len(applicationPerspectiveIds) == 0 {
+		return extension_kit.ToError("Target is missing the 'instana.application.id' attribute.", nil)
+	}
+	state.ApplicationPerspectiveId = applicationPerspectiveIds[0]
+	state.ApplicationPerspectiveLabel = state.ApplicationPerspectiveId
+	if labels := request.Target.Attributes["instana.application.label"]; len(labels) > 0 {
+		state.ApplicationPerspectiveLabel = labels[0]
+	}
+
+	duration := extutil.ToInt64(request.Config["duration"])
+	state.Start = time.Now()
+	state.End = time.Now().Add(time.Millisecond * time.Duration(duration))
+
+	state.Granularity = extutil.ToInt(request.Config["granularity"])
+	if state.Granularity <= 0 {
+		return extension_kit.ToError(fmt.Sprintf("Invalid granularity: '%v'.", request.Config["granularity"]), nil)
+	}
+	state.LatencyAggregation = extutil.ToString(request.Config["latencyAggregation"])
+	if state.LatencyAggregation == "" {
+		state.LatencyAggregation = "P99"
+	}
+	state.Thresholds = toThresholds(request.Config)
+	if request.Config["conditionCheckMode"] != nil {
+		state.ConditionCheckMode = fmt.Sprintf("%v", request.Config["conditionCheckMode"])
+	}
+	return prepareBaseline(state, request)
+}
+
+// metricsCheckParameters returns the duration, the given additional parameters and the parameters shared by all golden signal checks.
+func metricsCheckParameters(additional ...action_kit_api.ActionParameter) []action_kit_api.ActionParameter {
+	parameters := []action_kit_api.ActionParameter{
+		{
+			Name:         "duration",
+			Label:        "Duration",
+			Description:  new(""),
+			Type:         action_kit_api.ActionParameterTypeDuration,
+			DefaultValue: new("30s"),
+			Order:        new(1),
+			Required:     new(true),
+		},
+	}
+	parameters = append(parameters, additional...)
+	parameters = append(parameters, thresholdParameters(len(parameters)+1)...)
+	parameters = append(parameters, baselineParameters(len(parameters)+1)...)
+	parameters = append(parameters, extcommon.ConditionCheckModeParameter(len(parameters)+1), extcommon.GranularityParameter(len(parameters)+2))
+	return parameters
+}
+
+func thresholdParameters(order int) []action_kit_api.ActionParameter {
+	return []action_kit_api.ActionParameter{
+		{
+			Name:        "latencyAggregation",
+			Label:       "Latency Aggregation",
+			Description: new("How the latency of the calls is aggregated."),
+			Type:        action_kit_api.ActionParameterTypeString,
+			Options: new([]action_kit_api.ParameterOption{
+				action_kit_api.ExplicitParameterOption{Label: "Mean", Value: "MEAN"},
+				action_kit_api.ExplicitParameterOption{Label: "50th percentile", Value: "P50"},
+				action_kit_api.ExplicitParameterOption{Label: "90th percentile", Value: "P90"},
+				action_kit_api.ExplicitParameterOption{Label: "95th percentile", Value: "P95"},
+				action_kit_api.ExplicitParameterOption{Label: "99th percentile", Value: "P99"},
+			}),
+			DefaultValue: new("P99"),
+			Order:        new(order),
+			Required:     new(true),
+		},
+		{
+			Name:        "maxLatency",
+			Label:       "Max Latency (ms)",
+			Description: new("Fail if the aggregated latency exceeds this value. Leave empty to not check the latency."),
+			Type:        action_kit_api.ActionParameterTypeInteger,
+			Order:       new(order + 1),
+			Required:    new(false),
+		},
+		{
+			Name:        "maxErrorRate",
+			Label:       "Max Error Rate",
+			Description: new("Fail if the share of erroneous calls exceeds this value. Leave empty to not check the error rate."),
+			Type:        action_kit_api.ActionParameterTypePercentage,
+			Order:       new(order + 2),
+			Required:    new(false),
+		},
+		{
+			Name:        "minThroughput",
+			Label:       "Min Throughput (calls/min)",
+			Description: new("Fail if the number of calls per minute drops below this value. Leave empty to not check the throughput."),
+			Type:        action_kit_api.ActionParameterTypeInteger,
+			Order:       new(order + 3),
+			Required:    new(false),
+		},
+	}
+}
+
+func toThresholds(config map[string]any) Thresholds {
+	return Thresholds{
+		MaxLatency:    extcommon.OptionalFloat(config, "maxLatency"),
+		MaxErrorRate:  extcommon.OptionalFloat(config, "maxErrorRate"),
+		MinThroughput: extcommon.OptionalFloat(config, "minThroughput"),
+
+		MaxLatencyIncrease:   extcommon.OptionalFloat(config, "maxLatencyIncrease"),
+		MaxErrorRateIncrease: extcommon.OptionalFloat(config, "maxErrorRateIncrease"),
+		MaxThroughputDrop:    extcommon.OptionalFloat(config, "maxThroughputDrop"),
+	}
+}
+
+// Violations lists all thresholds the sample does not comply with. Relative thresholds are only checked if a baseline is given.
+func (t Thresholds) Violations(sample Sample, baseline *Sample) []string {
+	violations := make([]string, 0)
+	if t.MaxLatency != nil && sample.Latency != nil && *sample.Latency > *t.MaxLatency {
+		violations = append(violations, fmt.Sprintf("latency %.0fms exceeds %.0fms", *sample.Latency, *t.MaxLatency))
+	}
+	if t.MaxErrorRate != nil && sample.ErrorRate != nil && *sample.ErrorRate > *t.MaxErrorRate {
+		violations = append(violations, fmt.Sprintf("error rate %.2f%% exceeds %.2f%%", *sample.ErrorRate, *t.MaxErrorRate))
+	}
+	if t.MinThroughput != nil && sample.Throughput < *t.MinThroughput {
+		violations = append(violations, fmt.Sprintf("throughput %.0f calls/min is below %.0f calls/min", sample.Throughput, *t.MinThroughput))
+	}
+	if baseline != nil {
+		violations = append(violations, t.relativeViolations(sample, *baseline)...)
+	}
+	return violations
+}
+
+func goldenSignalMetrics(latencyAggregation string, granularity int) []types.MetricConfig {
+	return []types.MetricConfig{
+		{Metric: "calls", Aggregation: "SUM", Granularity: granularity},
+		{Metric: "erroneousCalls", Aggregation: "SUM", Granularity: granularity},
+		{Metric: "latency", Aggregation: latencyAggregation, Granularity: granularity},
+	}
+}
+
+func toTimeFrame(now time.Time, granularity int) types.TimeFrame {
+	return types.TimeFrame{
+		To:         now.UnixMilli(),
+		WindowSize: int64(granularity) * 1000,
+	}
+}
+
+// toSample derives the golden signals from the latest data points of the calls, erroneous calls and latency metrics.
+func toSample(metrics map[string][][]float64, latencyAggregation string, granularity int) Sample {
+	sample := Sample{}
+	calls, hasCalls := extcommon.LatestValue(metrics, "calls.sum")
+	if hasCalls {
+		sample.Throughput = calls * 60 / float64(granularity)
+	}
+	if !hasCalls || calls == 0 {
+		return sample
+	}
+	if latency, ok := extcommon.LatestValue(metrics, fmt.Sprintf("latency.%s", strings.ToLower(latencyAggregation))); ok {
+		sample.Latency = new(latency)
+	}
+	erroneousCalls, _ := extcommon.LatestValue(metrics, "erroneousCalls.sum")
+	sample.ErrorRate = new(erroneousCalls / calls * 100)
+	return sample
+}
+
+// getAllMetrics pages through the metrics of all entities matching the request.
+func getAllMetrics(ctx context.Context, request types.MetricsRequest, fetch func(ctx context.Context, request types.MetricsRequest) (*types.MetricsResponse, error)) ([]types.MetricsItem, error) {
+	result := make([]types.MetricsItem, 0)
+	pageSize := 200
+	page := 1
+	for {
+		request.Pagination = &types.Pagination{Page: page, PageSize: pageSize}
+		response, err := fetch(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, response.Items...)
+		if len(response.Items) < pageSize || len(result) >= response.TotalHits {
+			break
+		}
+		page = page + 1
+	}
+	log.Debug().Int("count", len(result)).Str("applicationPerspective", request.ApplicationId).Msg("Fetched metrics.")
+	return result, nil
+}
+
+func sampleToMetrics(name string, labels map[string]string, sample Sample, baseline *Sample, violations []string, now time.Time) []action_kit_api.Metric {
+	metrics := toMetrics(name, labels, sample, violations, now)
+	if baseline != nil {
+		baselineLabels := map[string]string{"series": "baseline"}
+		for key, value := range labels {
+			baselineLabels[key] = value
+		}
+		metrics = append(metrics, toMetrics(name, baselineLabels, *baseline, nil, now)...)
+	}
+	return metrics
+}
+
+func toMetrics(name string, labels map[string]string, sample Sample, violations []string, now time.Time) []action_kit_api.Metric {
+	values := map[string]*float64{
+		metricLatency:    sample.Latency,
+		metricErrorRate:  sample.ErrorRate,
+		metricThroughput: new(sample.Throughput),
+	}
+	units := map[string]string{
+		metricLatency:    "ms",
+		metricErrorRate:  "%",
+		metricThroughput: "calls/min",
+	}
+	violated := "false"
+	if len(violations) > 0 {
+		violated = "true"
+	}
+
+	metrics := make([]action_kit_api.Metric, 0, len(values))
+	for _, metric := range []string{metricLatency, metricErrorRate, metricThroughput} {
+		if values[metric] == nil {
+			continue
+		}
+		metricLabels := map[string]string{
+			"metric":   metric,
+			"unit":     units[metric],
+			"violated": violated,
+		}
+		for key, value := range labels {
+			metricLabels[key] = value
+		}
+		metrics = append(metrics, action_kit_api.Metric{
+			Name:      new(name),
+			Metric:    metricLabels,
+			Timestamp: now,
+			Value:     *values[metric],
+		})
+	}
+	return metrics
+}
+
+func metricsWidget(title string, metricName string, tooltip []action_kit_api.LineChartWidgetTooltipContent) action_kit_api.LineChartWidget {
+	return action_kit_api.LineChartWidget{
+		Type:  action_kit_api.ComSteadybitWidgetLineChart,
+		Title: title,
+		Identity: action_kit_api.LineChartWidgetIdentityConfig{
+			MetricName: metricName,
+			From:       "metric",
+			Mode:       action_kit_api.ComSteadybitWidgetLineChartIdentityModeWidgetPerValue,
+		},
+		Grouping: new(action_kit_api.LineChartWidgetGroupingConfig{
+			ShowSummary: new(true),
+			Groups: []action_kit_api.LineChartWidgetGroup{
+				{
+					Title: "Baseline",
+					Color: "info",
+					Matcher: action_kit_api.LineChartWidgetGroupMatcherKeyEqualsValue{
+						Type:  action_kit_api.ComSteadybitWidgetLineChartGroupMatcherKeyEqualsValue,
+						Key:   "series",
+						Value: "baseline",
+					},
+				},
+				{
+					Title: "Threshold violated",
+					Color: "danger",
+					Matcher: action_kit_api.LineChartWidgetGroupMatcherKeyEqualsValue{
+						Type:  action_kit_api.ComSteadybitWidgetLineChartGroupMatcherKeyEqualsValue,
+						Key:   "violated",
+						Value: "true",
+					},
+				},
+				{
+					Title: "Thresholds met",
+					Color: "success",
+					Matcher: action_kit_api.LineChartWidgetGroupMatcherFallback{
+						Type: action_kit_api.ComSteadybitWidgetLineChartGroupMatcherFallback,
+					},
+				},
+			},
+		}),
+		Tooltip: new(action_kit_api.LineChartWidgetTooltipConfig{
+			MetricValueTitle:  new("Value"),
+			AdditionalContent: append(tooltip, action_kit_api.LineChartWidgetTooltipContent{From: "unit", Title: "Unit"}),
+		}),
+	}
+}
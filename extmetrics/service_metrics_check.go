@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extmetrics
+
+import (
+	"context"
+	"fmt"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-instana/config"
+	"github.com/steadybit/extension-instana/extapplications"
+	"github.com/steadybit/extension-instana/extcommon"
+	"github.com/steadybit/extension-instana/types"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"time"
+)
+
+type ServiceMetricsCheckAction struct{}
+
+// Make sure action implements all required interfaces
+var (
+	_ action_kit_sdk.Action[ServiceMetricsCheckState]           = (*ServiceMetricsCheckAction)(nil)
+	_ action_kit_sdk.ActionWithStatus[ServiceMetricsCheckState] = (*ServiceMetricsCheckAction)(nil)
+)
+
+type ServiceMetricsCheckState struct {
+	MetricsCheckState
+	ServiceNameFilter string
+}
+
+func NewServiceMetricsCheckAction() action_kit_sdk.Action[ServiceMetricsCheckState] {
+	return &ServiceMetricsCheckAction{}
+}
+
+func (m *ServiceMetricsCheckAction) NewEmptyState() ServiceMetricsCheckState {
+	return ServiceMetricsCheckState{}
+}
+
+func (m *ServiceMetricsCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          ServiceMetricsCheckActionId,
+		Label:       "Service Metrics Check",
+		Description: "Checks the latency, error rate and throughput of each service of an application perspective in Instana.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        new(metricsCheckActionIcon),
+		TargetSelection: new(action_kit_api.TargetSelection{
+			TargetType:          extapplications.ApplicationPerspectiveTargetId,
+			QuantityRestriction: extutil.Ptr(action_kit_api.QuantityRestrictionAll),
+			SelectionTemplates: new([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label: "application perspective label",
+					Query: "instana.application.label=\"\"",
+				},
+			}),
+		}),
+		Technology:  new("Instana"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		Parameters: metricsCheckParameters(action_kit_api.ActionParameter{
+			Name:        "serviceNameFilter",
+			Label:       "Service Name Filter",
+			Description: new("Only check services whose name contains this text. Leave empty to check all services of the application perspective."),
+			Type:        action_kit_api.ActionParameterTypeString,
+			Required:    new(false),
+		}),
+		Widgets: new([]action_kit_api.Widget{
+			metricsWidget("Instana Service Metrics", "instana_service_metrics", []action_kit_api.LineChartWidgetTooltipContent{
+				{From: "service", Title: "Service"},
+				{From: "application", Title: "Application Perspective"},
+			}),
+		}),
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: new(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: new("5s"),
+		}),
+	}
+}
+
+func (m *ServiceMetricsCheckAction) Prepare(_ context.Context, state *ServiceMetricsCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	state.ServiceNameFilter = extutil.ToString(request.Config["serviceNameFilter"])
+	return nil, prepareMetricsCheck(&state.MetricsCheckState, request)
+}
+
+func (m *ServiceMetricsCheckAction) Start(ctx context.Context, state *ServiceMetricsCheckState) (*action_kit_api.StartResult, error) {
+	statusResult, err := ServiceMetricsCheckStatus(ctx, state, &config.Config)
+	if statusResult == nil {
+		return nil, err
+	}
+	startResult := action_kit_api.StartResult{
+		Artifacts: statusResult.Artifacts,
+		Error:     statusResult.Error,
+		Messages:  statusResult.Messages,
+		Metrics:   statusResult.Metrics,
+	}
+	return &startResult, err
+}
+
+func (m *ServiceMetricsCheckAction) Status(ctx context.Context, state *ServiceMetricsCheckState) (*action_kit_api.StatusResult, error) {
+	return ServiceMetricsCheckStatus(ctx, state, &config.Config)
+}
+
+type ServiceMetricsApi interface {
+	GetServiceMetrics(ctx context.Context, request types.MetricsRequest) (*types.MetricsResponse, error)
+}
+
+func ServiceMetricsCheckStatus(ctx context.Context, state *ServiceMetricsCheckState, api ServiceMetricsApi) (*action_kit_api.StatusResult, error) {
+	now := time.Now()
+	query := func(ctx context.Context, timeFrame types.TimeFrame, granularity int) ([]types.MetricsItem, error) {
+		return getAllMetrics(ctx, types.MetricsRequest{
+			ApplicationBoundaryScope: "ALL",
+			ApplicationId:            state.ApplicationPerspectiveId,
+			NameFilter:               state.ServiceNameFilter,
+			Metrics:                  goldenSignalMetrics(state.LatencyAggregation, granularity),
+			TimeFrame:                timeFrame,
+		}, api.GetServiceMetrics)
+	}
+	key := func(item types.MetricsItem) (string, bool) {
+		if item.Service == nil {
+			return "", false
+		}
+		return item.Service.Id, true
+	}
+
+	if err := state.ensureBaselines(ctx, query, key); err != nil {
+		return nil, extension_kit.ToError("Failed to get baseline service metrics from Instana.", err)
+	}
+	items, err := query(ctx, toTimeFrame(now, state.Granularity), state.Granularity)
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to get service metrics from Instana.", err)
+	}
+
+	violations := make([]string, 0)
+	metrics := make([]action_kit_api.Metric, 0, len(items)*3)
+	for _, item := range items {
+		if item.Service == nil {
+			continue
+		}
+		sample := toSample(item.Metrics, state.LatencyAggregation, state.Granularity)
+		baseline := state.baseline(item.Service.Id)
+		serviceViolations := state.Thresholds.Violations(sample, baseline)
+		for _, violation := range serviceViolations {
+			violations = append(violations, fmt.Sprintf("%s: %s", item.Service.Label, violation))
+		}
+		metrics = append(metrics, sampleToMetrics("instana_service_metrics", map[string]string{
+			"service":     item.Service.Label,
+			"application": state.ApplicationPerspectiveLabel,
+		}, sample, baseline, serviceViolations, now)...)
+	}
+
+	completed := now.After(state.End)
+	checkError := extcommon.EvaluateCondition(state.ConditionCheckMode, violations, completed, &state.ConditionCheckSuccess)
+
+	return &action_kit_api.StatusResult{
+		Completed: completed,
+		Error:     checkError,
+		Metrics:   new(metrics),
+	}, nil
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extmetrics
+
+import (
+	"context"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func (m *instanaApiMock) GetServiceMetrics(ctx context.Context, request types.MetricsRequest) (*types.MetricsResponse, error) {
+	args := m.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.MetricsResponse), args.Error(1)
+}
+
+func serviceMetrics(label string, calls float64, erroneousCalls float64, latency float64) types.MetricsItem {
+	return types.MetricsItem{
+		Service: &types.Service{Id: label + "-id", Label: label},
+		Metrics: map[string][][]float64{
+			"calls.sum":          {{1700000000000, calls}},
+			"erroneousCalls.sum": {{1700000000000, erroneousCalls}},
+			"latency.p99":        {{1700000000000, latency}},
+		},
+	}
+}
+
+func TestServiceMetricsReportsDegradedService(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetServiceMetrics", mock.Anything, mock.Anything).Return(&types.MetricsResponse{
+		Items: []types.MetricsItem{
+			serviceMetrics("catalog", 600, 0, 120),
+			serviceMetrics("payment", 600, 0, 1500),
+		},
+		TotalHits: 2,
+	}, nil)
+	state := ServiceMetricsCheckState{
+		MetricsCheckState: MetricsCheckState{
+			End:                         time.Now().Add(time.Minute),
+			ApplicationPerspectiveId:    "app-1",
+			ApplicationPerspectiveLabel: "shop",
+			Granularity:                 60,
+			LatencyAggregation:          "P99",
+			ConditionCheckMode:          conditionCheckModeAllTheTime,
+			Thresholds:                  Thresholds{MaxLatency: new(800.0)},
+		},
+		ServiceNameFilter: "a",
+	}
+
+	// When
+	result, err := ServiceMetricsCheckStatus(context.Background(), &state, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, result.Error)
+	require.Equal(t, "Thresholds violated: payment: latency 1500ms exceeds 800ms.", result.Error.Title)
+	require.Len(t, *result.Metrics, 6)
+	for _, metric := range *result.Metrics {
+		require.Equal(t, metric.Metric["service"] == "payment", metric.Metric["violated"] == "true")
+	}
+	request := mockedApi.Calls[0].Arguments.Get(1).(types.MetricsRequest)
+	require.Equal(t, "a", request.NameFilter)
+}
+
+func TestServiceMetricsFetchesAllPages(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	page1 := make([]types.MetricsItem, 0, 200)
+	for i := 0; i < 200; i++ {
+		page1 = append(page1, serviceMetrics("service", 60, 0, 100))
+	}
+	mockedApi.On("GetServiceMetrics", mock.Anything, mock.MatchedBy(func(r types.MetricsRequest) bool { return r.Pagination.Page == 1 })).Return(&types.MetricsResponse{Items: page1, TotalHits: 201}, nil)
+	mockedApi.On("GetServiceMetrics", mock.Anything, mock.MatchedBy(func(r types.MetricsRequest) bool { return r.Pagination.Page == 2 })).Return(&types.MetricsResponse{Items: []types.MetricsItem{serviceMetrics("last", 60, 0, 100)}, TotalHits: 201}, nil)
+	state := ServiceMetricsCheckState{MetricsCheckState: MetricsCheckState{
+		End:                time.Now().Add(time.Minute),
+		Granularity:        60,
+		LatencyAggregation: "P99",
+		ConditionCheckMode: conditionCheckModeAllTheTime,
+	}}
+
+	// When
+	result, err := ServiceMetricsCheckStatus(context.Background(), &state, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.Nil(t, result.Error)
+	require.Len(t, *result.Metrics, 201*3)
+	mockedApi.AssertNumberOfCalls(t, "GetServiceMetrics", 2)
+}
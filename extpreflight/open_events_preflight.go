@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extpreflight
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-instana/config"
+	"github.com/steadybit/extension-instana/extevents"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_sdk"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type OpenEventsPreflight struct {
+	results sync.Map
+}
+
+// Make sure preflight implements all required interfaces
+var (
+	_ preflight_kit_sdk.Preflight = (*OpenEventsPreflight)(nil)
+)
+
+func NewOpenEventsPreflight() preflight_kit_sdk.Preflight {
+	return &OpenEventsPreflight{}
+}
+
+func (p *OpenEventsPreflight) Describe() preflight_kit_api.PreflightDescription {
+	return preflight_kit_api.PreflightDescription{
+		Id:          OpenEventsPreflightId,
+		Label:       "Instana Open Events",
+		Description: "Prevents experiments from starting while Instana reports open incidents or issues for the targeted application perspectives.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        new(openEventsPreflightIcon),
+		Start:       preflight_kit_api.MutatingEndpointReference{},
+		Status: preflight_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: new("1s"),
+		},
+		Cancel: new(preflight_kit_api.MutatingEndpointReference{}),
+	}
+}
+
+func (p *OpenEventsPreflight) Start(ctx context.Context, request preflight_kit_api.StartPreflightRequestBody) (*preflight_kit_api.StartResult, error) {
+	applicationPerspectiveIds := getApplicationPerspectiveIds(request.ExperimentExecution)
+	result, err := CheckOpenEvents(ctx, applicationPerspectiveIds, config.Config.PreflightEventSeverityFilter, &config.Config)
+	if err != nil {
+		return nil, err
+	}
+	p.results.Store(request.PreflightActionExecutionId.String(), result)
+	return &preflight_kit_api.StartResult{}, nil
+}
+
+func (p *OpenEventsPreflight) Status(_ context.Context, request preflight_kit_api.StatusPreflightRequestBody) (*preflight_kit_api.StatusResult, error) {
+	result, ok := p.results.LoadAndDelete(request.PreflightActionExecutionId.String())
+	if !ok {
+		// The result is kept in memory only, e.g. a restart of the extension between Start and Status loses it.
+		return nil, fmt.Errorf("no result of the open events check found for preflight execution %s", request.PreflightActionExecutionId)
+	}
+	return result.(*preflight_kit_api.StatusResult), nil
+}
+
+func (p *OpenEventsPreflight) Cancel(_ context.Context, request preflight_kit_api.CancelPreflightRequestBody) (*preflight_kit_api.CancelResult, error) {
+	p.results.Delete(request.PreflightActionExecutionId.String())
+	return &preflight_kit_api.CancelResult{}, nil
+}
+
+// getApplicationPerspectiveIds collects the application perspectives targeted by any step of the experiment.
+func getApplicationPerspectiveIds(execution preflight_kit_api.ExperimentExecutionAO) []string {
+	seen := make(map[string]bool)
+	ids := make([]string, 0)
+	for _, step := range execution.Steps {
+		for _, target := range step.TargetExecutions {
+			for _, id := range target.TargetAttributes["instana.application.id"] {
+				if !seen[id] {
+					seen[id] = true
+					ids = append(ids, id)
+				}
+			}
+		}
+	}
+	return ids
+}
+
+// CheckOpenEvents denies the experiment if any open incident or issue of at least the given severity exists for one of the application perspectives.
+func CheckOpenEvents(ctx context.Context, applicationPerspectiveIds []string, severityFilter string, api extevents.EventsApi) (*preflight_kit_api.StatusResult, error) {
+	if len(applicationPerspectiveIds) == 0 {
+		return &preflight_kit_api.StatusResult{Completed: true}, nil
+	}
+
+	minSeverity, ok := extevents.ToSeverity(severityFilter)
+	if !ok {
+		return nil, fmt.Errorf("unknown preflight event severity filter: '%s'", severityFilter)
+	}
+
+	snapshotIds := make(map[string]bool)
+	for _, applicationPerspectiveId := range applicationPerspectiveIds {
+		ids, err := api.GetSnapshotIds(ctx, applicationPerspectiveId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get snapshot-ids from Instana: %w", err)
+		}
+		for _, id := range ids {
+			snapshotIds[id] = true
+		}
+	}
+
+	now := time.Now()
+	events, err := api.GetEvents(ctx, now.Add(-1*time.Minute), now, []string{"INCIDENT", "ISSUE"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events from Instana: %w", err)
+	}
+
+	openEvents := make([]types.Event, 0)
+	for _, event := range events {
+		if event.Severity >= minSeverity && snapshotIds[event.SnapshotId] && event.State != "closed" {
+			openEvents = append(openEvents, event)
+		}
+	}
+	log.Debug().Int("applicationPerspectives", len(applicationPerspectiveIds)).Int("openEvents", len(openEvents)).Msg("Checked open events.")
+
+	if len(openEvents) == 0 {
+		return &preflight_kit_api.StatusResult{Completed: true}, nil
+	}
+
+	descriptions := make([]string, 0, len(openEvents))
+	for _, event := range openEvents {
+		descriptions = append(descriptions, fmt.Sprintf("%s - %s (%s, severity %d)", event.Problem, event.EntityLabel, event.Type, event.Severity))
+	}
+	sort.Strings(descriptions)
+
+	return &preflight_kit_api.StatusResult{
+		Completed: true,
+		Error: &preflight_kit_api.PreflightKitError{
+			Title:  fmt.Sprintf("Instana reports %d open events for the targeted application perspectives.", len(openEvents)),
+			Detail: new(strings.Join(descriptions, "\n")),
+			Status: new(preflight_kit_api.Failed),
+		},
+	}, nil
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extpreflight
+
+import (
+	"context"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type instanaApiMock struct {
+	mock.Mock
+}
+
+func (m *instanaApiMock) GetEvents(ctx context.Context, from time.Time, to time.Time, eventTypeFilters []string) ([]types.Event, error) {
+	args := m.Called(ctx, from, to, eventTypeFilters)
+	return args.Get(0).([]types.Event), args.Error(1)
+}
+
+func (m *instanaApiMock) GetSnapshotIds(ctx context.Context, applicationPerspectiveId string) ([]string, error) {
+	args := m.Called(ctx, applicationPerspectiveId)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func TestOpenEventAboveThresholdDeniesExperiment(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetSnapshotIds", mock.Anything, "app-1").Return([]string{"snapshot-1"}, nil)
+	mockedApi.On("GetEvents", mock.Anything, mock.Anything, mock.Anything, []string{"INCIDENT", "ISSUE"}).Return([]types.Event{
+		{EventId: "e1", SnapshotId: "snapshot-1", Severity: 10, State: "open", Problem: "Pod not ready", Type: "issue"},
+		{EventId: "e2", SnapshotId: "snapshot-1", Severity: 5, State: "open", Problem: "Slow calls", Type: "issue"},
+		{EventId: "e3", SnapshotId: "snapshot-1", Severity: 10, State: "closed", Problem: "Resolved", Type: "issue"},
+		{EventId: "e4", SnapshotId: "snapshot-other", Severity: 10, State: "open", Problem: "Other app", Type: "issue"},
+	}, nil)
+
+	// When
+	result, err := CheckOpenEvents(context.Background(), []string{"app-1"}, "critical", mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.True(t, result.Completed)
+	require.NotNil(t, result.Error)
+	require.Equal(t, "Instana reports 1 open events for the targeted application perspectives.", result.Error.Title)
+	require.Contains(t, *result.Error.Detail, "Pod not ready")
+}
+
+func TestNoOpenEventsAllowsExperiment(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetSnapshotIds", mock.Anything, "app-1").Return([]string{"snapshot-1"}, nil)
+	mockedApi.On("GetEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]types.Event{
+		{EventId: "e1", SnapshotId: "snapshot-1", Severity: 5, State: "open"},
+	}, nil)
+
+	// When
+	result, err := CheckOpenEvents(context.Background(), []string{"app-1"}, "critical", mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.True(t, result.Completed)
+	require.Nil(t, result.Error)
+}
+
+func TestExperimentWithoutApplicationPerspectivesIsNotChecked(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+
+	// When
+	result, err := CheckOpenEvents(context.Background(), []string{}, "critical", mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.Nil(t, result.Error)
+	mockedApi.AssertNotCalled(t, "GetEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStatusWithoutStoredResultFails(t *testing.T) {
+	// Given
+	preflight := &OpenEventsPreflight{}
+
+	// When
+	result, err := preflight.Status(context.Background(), preflight_kit_api.StatusPreflightRequestBody{})
+
+	// Then
+	require.Nil(t, result)
+	require.ErrorContains(t, err, "no result of the open events check found")
+}
@@ -0,0 +1,250 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extservices
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-instana/config"
+	"github.com/steadybit/extension-instana/extcommon"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/steadybit/extension-kit/extbuild"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type endpointDiscovery struct {
+	api GetEndpointsApi
+	// lastKnownGood is the result of the last complete discovery, served again while Instana requests fail.
+	lastKnownGood extcommon.LastKnownGoodTargets
+}
+
+var (
+	_ discovery_kit_sdk.TargetDescriber    = (*endpointDiscovery)(nil)
+	_ discovery_kit_sdk.AttributeDescriber = (*endpointDiscovery)(nil)
+)
+
+func NewEndpointDiscovery() discovery_kit_sdk.TargetDiscovery {
+	discovery := &endpointDiscovery{api: &config.Config}
+	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsInterval(context.Background(), 1*time.Minute),
+	)
+}
+
+func (d *endpointDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id: EndpointTargetId,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			CallInterval: new("1m"),
+		},
+	}
+}
+
+func (d *endpointDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       EndpointTargetId,
+		Label:    discovery_kit_api.PluralLabel{One: "Instana Endpoint", Other: "Instana Endpoints"},
+		Category: new("monitoring"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     new(serviceIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "steadybit.label"},
+				{Attribute: "instana.service.label"},
+				{Attribute: "instana.endpoint.type"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{
+					Attribute: "steadybit.label",
+					Direction: "ASC",
+				},
+			},
+		},
+	}
+}
+
+func (d *endpointDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDescription {
+	return []discovery_kit_api.AttributeDescription{
+		{
+			Attribute: "instana.endpoint.id",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana endpoint id",
+				Other: "Instana endpoint ids",
+			},
+		},
+		{
+			Attribute: "instana.endpoint.label",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana endpoint label",
+				Other: "Instana endpoint labels",
+			},
+		},
+		{
+			Attribute: "instana.endpoint.type",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana endpoint type",
+				Other: "Instana endpoint types",
+			},
+		},
+		{
+			Attribute: "instana.endpoint.synthetic",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana endpoint synthetic",
+				Other: "Instana endpoint synthetic",
+			},
+		},
+		{
+			Attribute: "instana.endpoint.http.method",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana endpoint HTTP method",
+				Other: "Instana endpoint HTTP methods",
+			},
+		},
+		{
+			Attribute: "instana.endpoint.http.path",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana endpoint HTTP path",
+				Other: "Instana endpoint HTTP paths",
+			},
+		},
+		{
+			Attribute: "instana.endpoint.stale-since",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana endpoint stale since",
+				Other: "Instana endpoints stale since",
+			},
+		},
+	}
+}
+
+func (d *endpointDiscovery) DiscoverTargets(ctx context.Context) ([]discovery_kit_api.Target, error) {
+	return d.discover(ctx, newEndpointFilter(config.Config.DiscoveryEndpointsInclude, config.Config.DiscoveryEndpointsExclude))
+}
+
+// discover returns the current endpoints or, if the discovery fails, the last complete result marked with the
+// instana.endpoint.stale-since attribute.
+func (d *endpointDiscovery) discover(ctx context.Context, filter endpointFilter) ([]discovery_kit_api.Target, error) {
+	targets, err := getAllEndpoints(ctx, d.api, filter)
+	return d.lastKnownGood.Resolve(targets, err, "instana.endpoint.stale-since", "endpoints")
+}
+
+type GetEndpointsApi interface {
+	GetServices(ctx context.Context, page int, pageSize int) (*types.ServiceResponse, error)
+	GetEndpoints(ctx context.Context, page int, pageSize int) (*types.EndpointResponse, error)
+}
+
+// getAllEndpoints returns the endpoints passing the filter. Any failed request fails the whole discovery, as missing
+// service labels would change the result of the filter and a truncated list would drop endpoints.
+func getAllEndpoints(ctx context.Context, api GetEndpointsApi, filter endpointFilter) ([]discovery_kit_api.Target, error) {
+	start := time.Now()
+	services, err := fetchServices(func(page int) (*types.ServiceResponse, error) {
+		return api.GetServices(ctx, page, pageSize)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get services from Instana: %w", err)
+	}
+	serviceLabels := make(map[string]string, len(services))
+	for _, service := range services {
+		serviceLabels[service.Id] = service.Label
+	}
+
+	endpoints, err := fetchAll(func(page int) ([]types.Endpoint, int, error) {
+		response, err := api.GetEndpoints(ctx, page, pageSize)
+		if err != nil {
+			return nil, 0, err
+		}
+		return response.Items, response.TotalHits, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoints from Instana: %w", err)
+	}
+
+	result := make([]discovery_kit_api.Target, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		serviceLabel := serviceLabels[endpoint.ServiceId]
+		if !filter.matches(serviceLabel, endpoint.Label) {
+			continue
+		}
+		result = append(result, toEndpointTarget(endpoint, serviceLabel))
+	}
+	log.Debug().Msgf("Discovery took %s, returning %d of %d endpoints.", time.Since(start), len(result), len(endpoints))
+	return result, nil
+}
+
+// endpointFilter keeps the endpoints whose service or endpoint label matches any include and no exclude expression.
+type endpointFilter struct {
+	includes []*regexp.Regexp
+	excludes []*regexp.Regexp
+}
+
+func newEndpointFilter(includes []string, excludes []string) endpointFilter {
+	return endpointFilter{
+		includes: compileAll(includes),
+		excludes: compileAll(excludes),
+	}
+}
+
+func compileAll(expressions []string) []*regexp.Regexp {
+	result := make([]*regexp.Regexp, 0, len(expressions))
+	for _, expression := range expressions {
+		compiled, err := regexp.Compile(strings.TrimSpace(expression))
+		if err != nil {
+			log.Warn().Err(err).Str("expression", expression).Msg("Ignoring invalid endpoint filter expression.")
+			continue
+		}
+		result = append(result, compiled)
+	}
+	return result
+}
+
+func (f endpointFilter) matches(serviceLabel string, endpointLabel string) bool {
+	for _, exclude := range f.excludes {
+		if exclude.MatchString(serviceLabel) || exclude.MatchString(endpointLabel) {
+			return false
+		}
+	}
+	if len(f.includes) == 0 {
+		return true
+	}
+	for _, include := range f.includes {
+		if include.MatchString(serviceLabel) || include.MatchString(endpointLabel) {
+			return true
+		}
+	}
+	return false
+}
+
+func toEndpointTarget(endpoint types.Endpoint, serviceLabel string) discovery_kit_api.Target {
+	attributes := make(map[string][]string)
+	attributes["steadybit.label"] = []string{endpoint.Label}
+	attributes["instana.endpoint.id"] = []string{endpoint.Id}
+	attributes["instana.endpoint.label"] = []string{endpoint.Label}
+	attributes["instana.endpoint.synthetic"] = []string{fmt.Sprintf("%t", endpoint.Synthetic)}
+	if endpoint.Type != "" {
+		attributes["instana.endpoint.type"] = []string{endpoint.Type}
+	}
+	if endpoint.ServiceId != "" {
+		attributes["instana.service.id"] = []string{endpoint.ServiceId}
+	}
+	if serviceLabel != "" {
+		attributes["instana.service.label"] = []string{serviceLabel}
+	}
+	// HTTP endpoints are labeled like 'GET /api/orders/{id}'
+	if method, path, ok := strings.Cut(endpoint.Label, " "); ok && endpoint.Type == "HTTP" && strings.HasPrefix(path, "/") {
+		attributes["instana.endpoint.http.method"] = []string{method}
+		attributes["instana.endpoint.http.path"] = []string{path}
+	}
+
+	return discovery_kit_api.Target{
+		Id:         endpoint.Id,
+		Label:      endpoint.Label,
+		TargetType: EndpointTargetId,
+		Attributes: attributes,
+	}
+}
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extservices
+
+import (
+	"context"
+	"errors"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func (m *instanaApiMock) GetEndpoints(ctx context.Context, page int, pageSize int) (*types.EndpointResponse, error) {
+	args := m.Called(ctx, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.EndpointResponse), args.Error(1)
+}
+
+func mockEndpoints(mockedApi *instanaApiMock) {
+	mockedApi.On("GetServices", mock.Anything, 1, mock.Anything).Return(&types.ServiceResponse{
+		Items:     []types.Service{{Id: "s1", Label: "catalog"}, {Id: "s2", Label: "payment"}},
+		TotalHits: 2,
+	}, nil)
+	mockedApi.On("GetEndpoints", mock.Anything, 1, mock.Anything).Return(&types.EndpointResponse{
+		Items: []types.Endpoint{
+			{Id: "e1", Label: "GET /api/products/{id}", Type: "HTTP", ServiceId: "s1"},
+			{Id: "e2", Label: "orders", Type: "MESSAGING", ServiceId: "s1"},
+		},
+		TotalHits: 3,
+	}, nil)
+	mockedApi.On("GetEndpoints", mock.Anything, 2, mock.Anything).Return(&types.EndpointResponse{
+		Items:     []types.Endpoint{{Id: "e3", Label: "POST /charge", Type: "HTTP", ServiceId: "s2", Synthetic: true}},
+		TotalHits: 3,
+	}, nil)
+}
+
+func TestEndpointsArePagedAndEnriched(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockEndpoints(mockedApi)
+
+	// When
+	targets, err := getAllEndpoints(context.Background(), mockedApi, newEndpointFilter(nil, nil))
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, targets, 3)
+	require.Equal(t, EndpointTargetId, targets[0].TargetType)
+	require.Equal(t, []string{"catalog"}, targets[0].Attributes["instana.service.label"])
+	require.Equal(t, []string{"s1"}, targets[0].Attributes["instana.service.id"])
+	require.Equal(t, []string{"HTTP"}, targets[0].Attributes["instana.endpoint.type"])
+	require.Equal(t, []string{"GET"}, targets[0].Attributes["instana.endpoint.http.method"])
+	require.Equal(t, []string{"/api/products/{id}"}, targets[0].Attributes["instana.endpoint.http.path"])
+	require.Equal(t, []string{"false"}, targets[0].Attributes["instana.endpoint.synthetic"])
+	require.NotContains(t, targets[1].Attributes, "instana.endpoint.http.method")
+	require.Equal(t, []string{"true"}, targets[2].Attributes["instana.endpoint.synthetic"])
+	mockedApi.AssertNumberOfCalls(t, "GetEndpoints", 2)
+}
+
+func TestEndpointsAreFiltered(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockEndpoints(mockedApi)
+
+	// When
+	targets, err := getAllEndpoints(context.Background(), mockedApi, newEndpointFilter([]string{"^catalog$", "charge"}, []string{"^orders$", "("}))
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, targets, 2)
+	require.Equal(t, "e1", targets[0].Id)
+	require.Equal(t, "e3", targets[1].Id)
+}
+
+func TestFailedServicesFailTheEndpointDiscovery(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetServices", mock.Anything, 1, mock.Anything).Return(nil, errors.New("oops"))
+
+	// When
+	targets, err := getAllEndpoints(context.Background(), mockedApi, newEndpointFilter(nil, []string{"^catalog$"}))
+
+	// Then
+	require.ErrorContains(t, err, "oops")
+	require.Nil(t, targets)
+	mockedApi.AssertNotCalled(t, "GetEndpoints", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEndpointDiscoveryServesLastKnownGoodResultIfPagingFails(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetServices", mock.Anything, 1, mock.Anything).Return(&types.ServiceResponse{
+		Items:     []types.Service{{Id: "s1", Label: "catalog"}},
+		TotalHits: 1,
+	}, nil)
+	mockedApi.On("GetEndpoints", mock.Anything, 1, mock.Anything).Return(&types.EndpointResponse{
+		Items:     []types.Endpoint{{Id: "e1", Label: "GET /api/products", Type: "HTTP", ServiceId: "s1"}},
+		TotalHits: 2,
+	}, nil)
+	mockedApi.On("GetEndpoints", mock.Anything, 2, mock.Anything).Return(&types.EndpointResponse{
+		Items:     []types.Endpoint{{Id: "e2", Label: "POST /api/orders", Type: "HTTP", ServiceId: "s1"}},
+		TotalHits: 2,
+	}, nil).Once()
+	mockedApi.On("GetEndpoints", mock.Anything, 2, mock.Anything).Return(nil, errors.New("oops"))
+	discovery := &endpointDiscovery{api: mockedApi}
+
+	// When
+	_, err := discovery.discover(context.Background(), newEndpointFilter(nil, nil))
+	require.NoError(t, err)
+	targets, err := discovery.discover(context.Background(), newEndpointFilter(nil, nil))
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, targets, 2)
+	require.Equal(t, []string{discovery.lastKnownGood.StaleSince().Format(time.RFC3339)}, targets[1].Attributes["instana.endpoint.stale-since"])
+}
@@ -0,0 +1,224 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extservices
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-instana/config"
+	"github.com/steadybit/extension-instana/extcommon"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/steadybit/extension-kit/extbuild"
+	"time"
+)
+
+const pageSize = 100
+
+type serviceDiscovery struct {
+	// applicationServices is shared with the application perspective discovery, which fetches the same services.
+	applicationServices *extcommon.ApplicationServicesCache
+}
+
+var (
+	_ discovery_kit_sdk.TargetDescriber    = (*serviceDiscovery)(nil)
+	_ discovery_kit_sdk.AttributeDescriber = (*serviceDiscovery)(nil)
+)
+
+func NewServiceDiscovery() discovery_kit_sdk.TargetDiscovery {
+	discovery := &serviceDiscovery{applicationServices: extcommon.SharedApplicationServices}
+	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsInterval(context.Background(), 1*time.Minute),
+	)
+}
+
+func (d *serviceDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id: ServiceTargetId,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			CallInterval: new("1m"),
+		},
+	}
+}
+
+func (d *serviceDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       ServiceTargetId,
+		Label:    discovery_kit_api.PluralLabel{One: "Instana Service", Other: "Instana Services"},
+		Category: new("monitoring"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     new(serviceIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "steadybit.label"},
+				{Attribute: "instana.service.technology"},
+				{Attribute: "instana.application.label"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{
+					Attribute: "steadybit.label",
+					Direction: "ASC",
+				},
+			},
+		},
+	}
+}
+
+func (d *serviceDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDescription {
+	return []discovery_kit_api.AttributeDescription{
+		{
+			Attribute: "instana.service.id",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana service id",
+				Other: "Instana service ids",
+			},
+		},
+		{
+			Attribute: "instana.service.label",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana service label",
+				Other: "Instana service labels",
+			},
+		},
+		{
+			Attribute: "instana.service.technology",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana service technology",
+				Other: "Instana service technologies",
+			},
+		},
+		{
+			Attribute: "instana.service.type",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana service type",
+				Other: "Instana service types",
+			},
+		},
+		{
+			Attribute: "instana.application.id",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana application perspective id",
+				Other: "Instana application perspective ids",
+			},
+		},
+		{
+			Attribute: "instana.application.label",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana application perspective label",
+				Other: "Instana application perspective labels",
+			},
+		},
+	}
+}
+
+func (d *serviceDiscovery) DiscoverTargets(ctx context.Context) ([]discovery_kit_api.Target, error) {
+	return getAllServices(ctx, &config.Config, d.applicationServices)
+}
+
+type GetServicesApi interface {
+	GetServices(ctx context.Context, page int, pageSize int) (*types.ServiceResponse, error)
+	GetApplicationPerspectives(ctx context.Context, page int, pageSize int) (*types.ApplicationPerspectiveResponse, error)
+	GetApplicationServices(ctx context.Context, applicationPerspectiveId string, page int, pageSize int) (*types.ServiceResponse, error)
+}
+
+// getAllServices returns all services with the application perspectives they belong to. Any failed request fails the
+// whole discovery to not publish services without their application perspectives.
+func getAllServices(ctx context.Context, api GetServicesApi, applicationServices *extcommon.ApplicationServicesCache) ([]discovery_kit_api.Target, error) {
+	start := time.Now()
+	services, err := fetchServices(func(page int) (*types.ServiceResponse, error) {
+		return api.GetServices(ctx, page, pageSize)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get services from Instana: %w", err)
+	}
+
+	applications, err := getServiceApplications(ctx, api, applicationServices)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]discovery_kit_api.Target, 0, len(services))
+	for _, service := range services {
+		result = append(result, toTarget(service, applications[service.Id]))
+	}
+	log.Debug().Msgf("Discovery took %s, returning %d services.", time.Since(start), len(result))
+	return result, nil
+}
+
+// getServiceApplications returns the application perspectives each service belongs to, keyed by service id.
+func getServiceApplications(ctx context.Context, api GetServicesApi, applicationServices *extcommon.ApplicationServicesCache) (map[string][]types.ApplicationPerspective, error) {
+	result := make(map[string][]types.ApplicationPerspective)
+	for page := 1; ; page++ {
+		response, err := api.GetApplicationPerspectives(ctx, page, pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get application perspectives from Instana for page %d and page size %d: %w", page, pageSize, err)
+		}
+
+		for _, perspective := range response.Items {
+			services, err := applicationServices.Get(ctx, api, perspective.Id, pageSize)
+			if err != nil {
+				return nil, err
+			}
+			for _, service := range services {
+				result[service.Id] = append(result[service.Id], perspective)
+			}
+		}
+
+		_, hasMore := response.Links["next"]
+		if len(response.Items) == 0 || !hasMore {
+			return result, nil
+		}
+	}
+}
+
+// fetchAll pages through the items until all hits are fetched and returns the items fetched so far on error.
+func fetchAll[T any](fetch func(page int) ([]T, int, error)) ([]T, error) {
+	result := make([]T, 0)
+	for page := 1; ; page++ {
+		items, totalHits, err := fetch(page)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, items...)
+		if len(items) == 0 || len(result) >= totalHits {
+			return result, nil
+		}
+	}
+}
+
+func fetchServices(fetch func(page int) (*types.ServiceResponse, error)) ([]types.Service, error) {
+	return fetchAll(func(page int) ([]types.Service, int, error) {
+		response, err := fetch(page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return response.Items, response.TotalHits, nil
+	})
+}
+
+func toTarget(service types.Service, applications []types.ApplicationPerspective) discovery_kit_api.Target {
+	attributes := make(map[string][]string)
+	attributes["steadybit.label"] = []string{service.Label}
+	attributes["instana.service.id"] = []string{service.Id}
+	attributes["instana.service.label"] = []string{service.Label}
+	if len(service.Technologies) > 0 {
+		attributes["instana.service.technology"] = service.Technologies
+	}
+	if len(service.Types) > 0 {
+		attributes["instana.service.type"] = service.Types
+	}
+	for _, application := range applications {
+		attributes["instana.application.id"] = append(attributes["instana.application.id"], application.Id)
+		attributes["instana.application.label"] = append(attributes["instana.application.label"], application.Label)
+	}
+
+	return discovery_kit_api.Target{
+		Id:         service.Id,
+		Label:      service.Label,
+		TargetType: ServiceTargetId,
+		Attributes: attributes,
+	}
+}
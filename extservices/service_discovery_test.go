@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extservices
+
+import (
+	"context"
+	"errors"
+	"github.com/steadybit/extension-instana/extcommon"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type instanaApiMock struct {
+	mock.Mock
+}
+
+func (m *instanaApiMock) GetServices(ctx context.Context, page int, pageSize int) (*types.ServiceResponse, error) {
+	args := m.Called(ctx, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.ServiceResponse), args.Error(1)
+}
+
+func (m *instanaApiMock) GetApplicationPerspectives(ctx context.Context, page int, pageSize int) (*types.ApplicationPerspectiveResponse, error) {
+	args := m.Called(ctx, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.ApplicationPerspectiveResponse), args.Error(1)
+}
+
+func (m *instanaApiMock) GetApplicationServices(ctx context.Context, applicationPerspectiveId string, page int, pageSize int) (*types.ServiceResponse, error) {
+	args := m.Called(ctx, applicationPerspectiveId, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.ServiceResponse), args.Error(1)
+}
+
+func TestServicesArePagedAndAssociatedWithApplications(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetServices", mock.Anything, 1, mock.Anything).Return(&types.ServiceResponse{
+		Items:     []types.Service{{Id: "s1", Label: "catalog", Types: []string{"HTTP"}, Technologies: []string{"java"}}},
+		TotalHits: 2,
+	}, nil)
+	mockedApi.On("GetServices", mock.Anything, 2, mock.Anything).Return(&types.ServiceResponse{
+		Items:     []types.Service{{Id: "s2", Label: "payment"}},
+		TotalHits: 2,
+	}, nil)
+	mockedApi.On("GetApplicationPerspectives", mock.Anything, 1, mock.Anything).Return(&types.ApplicationPerspectiveResponse{
+		Items: []types.ApplicationPerspective{{Id: "app1", Label: "shop"}, {Id: "app2", Label: "backoffice"}},
+	}, nil)
+	mockedApi.On("GetApplicationServices", mock.Anything, "app1", 1, mock.Anything).Return(&types.ServiceResponse{
+		Items:     []types.Service{{Id: "s1"}, {Id: "s2"}},
+		TotalHits: 2,
+	}, nil)
+	mockedApi.On("GetApplicationServices", mock.Anything, "app2", 1, mock.Anything).Return(&types.ServiceResponse{
+		Items:     []types.Service{{Id: "s1"}},
+		TotalHits: 1,
+	}, nil)
+
+	// When
+	targets, err := getAllServices(context.Background(), mockedApi, extcommon.NewApplicationServicesCache(time.Minute))
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, targets, 2)
+	require.Equal(t, "s1", targets[0].Id)
+	require.Equal(t, ServiceTargetId, targets[0].TargetType)
+	require.Equal(t, []string{"catalog"}, targets[0].Attributes["instana.service.label"])
+	require.Equal(t, []string{"java"}, targets[0].Attributes["instana.service.technology"])
+	require.Equal(t, []string{"HTTP"}, targets[0].Attributes["instana.service.type"])
+	require.Equal(t, []string{"app1", "app2"}, targets[0].Attributes["instana.application.id"])
+	require.Equal(t, []string{"shop", "backoffice"}, targets[0].Attributes["instana.application.label"])
+	require.Equal(t, []string{"app1"}, targets[1].Attributes["instana.application.id"])
+	mockedApi.AssertNumberOfCalls(t, "GetServices", 2)
+}
+
+func TestServiceErrorResponseFailsInsteadOfReturningIntermediateResult(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetServices", mock.Anything, 1, mock.Anything).Return(&types.ServiceResponse{
+		Items:     []types.Service{{Id: "s1", Label: "catalog"}},
+		TotalHits: 2,
+	}, nil)
+	mockedApi.On("GetServices", mock.Anything, 2, mock.Anything).Return(nil, errors.New("oops"))
+
+	// When
+	targets, err := getAllServices(context.Background(), mockedApi, extcommon.NewApplicationServicesCache(time.Minute))
+
+	// Then
+	require.ErrorContains(t, err, "oops")
+	require.Nil(t, targets)
+}
+
+func TestApplicationServicesErrorFailsInsteadOfDroppingApplications(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetServices", mock.Anything, 1, mock.Anything).Return(&types.ServiceResponse{
+		Items:     []types.Service{{Id: "s1", Label: "catalog"}},
+		TotalHits: 1,
+	}, nil)
+	mockedApi.On("GetApplicationPerspectives", mock.Anything, 1, mock.Anything).Return(&types.ApplicationPerspectiveResponse{
+		Items: []types.ApplicationPerspective{{Id: "app1", Label: "shop"}},
+	}, nil)
+	mockedApi.On("GetApplicationServices", mock.Anything, "app1", 1, mock.Anything).Return(nil, errors.New("oops"))
+
+	// When
+	targets, err := getAllServices(context.Background(), mockedApi, extcommon.NewApplicationServicesCache(time.Minute))
+
+	// Then
+	require.ErrorContains(t, err, "oops")
+	require.Nil(t, targets)
+}
@@ -0,0 +1,337 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extslo
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-instana/config"
+	"github.com/steadybit/extension-instana/extcommon"
+	"github.com/steadybit/extension-instana/types"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"strings"
+	"time"
+)
+
+const sloConfigPageSize = 100
+
+type SloCheckAction struct{}
+
+// Make sure action implements all required interfaces
+var (
+	_ action_kit_sdk.Action[SloCheckState]           = (*SloCheckAction)(nil)
+	_ action_kit_sdk.ActionWithStatus[SloCheckState] = (*SloCheckAction)(nil)
+)
+
+type SloCheckState struct {
+	Start                time.Time
+	End                  time.Time
+	SloId                string
+	SloName              string
+	ReportWindowInMillis int64
+	MinSli               *float64
+	MaxErrorBudgetBurn   *float64
+	Before               *types.SloReport
+	After                *types.SloReport
+}
+
+func NewSloCheckAction() action_kit_sdk.Action[SloCheckState] {
+	return &SloCheckAction{}
+}
+
+func (m *SloCheckAction) NewEmptyState() SloCheckState {
+	return SloCheckState{}
+}
+
+func (m *SloCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          SloCheckActionId,
+		Label:       "SLO Check",
+		Description: "Checks that an Instana SLO is met and that the error budget burned during the step stays within a limit.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        new(sloCheckIcon),
+		Technology:  new("Instana"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  new(""),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: new("30s"),
+				Order:        new(1),
+				Required:     new(true),
+			},
+			{
+				Name:        "slo",
+				Label:       "SLO",
+				Description: new("Id or name of the SLO configuration in Instana."),
+				Type:        action_kit_api.ActionParameterTypeString,
+				Order:       new(2),
+				Required:    new(true),
+			},
+			{
+				Name:        "minSli",
+				Label:       "Min SLI",
+				Description: new("Fail if the service level indicator drops below this value. Leave empty to use the target of the SLO."),
+				Type:        action_kit_api.ActionParameterTypePercentage,
+				Order:       new(3),
+				Required:    new(false),
+			},
+			{
+				Name:        "maxErrorBudgetBurn",
+				Label:       "Max Error Budget Burn",
+				Description: new("Fail if more than this share of the total error budget is burned during the step. Leave empty to not check the burn."),
+				Type:        action_kit_api.ActionParameterTypePercentage,
+				Order:       new(4),
+				Required:    new(false),
+			},
+		},
+		Widgets: new([]action_kit_api.Widget{
+			action_kit_api.LineChartWidget{
+				Type:  action_kit_api.ComSteadybitWidgetLineChart,
+				Title: "Instana SLO",
+				Identity: action_kit_api.LineChartWidgetIdentityConfig{
+					MetricName: "instana_slo",
+					From:       "metric",
+					Mode:       action_kit_api.ComSteadybitWidgetLineChartIdentityModeWidgetPerValue,
+				},
+				Grouping: new(action_kit_api.LineChartWidgetGroupingConfig{
+					ShowSummary: new(true),
+					Groups: []action_kit_api.LineChartWidgetGroup{
+						{
+							Title: "SLO violated",
+							Color: "danger",
+							Matcher: action_kit_api.LineChartWidgetGroupMatcherKeyEqualsValue{
+								Type:  action_kit_api.ComSteadybitWidgetLineChartGroupMatcherKeyEqualsValue,
+								Key:   "violated",
+								Value: "true",
+							},
+						},
+						{
+							Title: "SLO met",
+							Color: "success",
+							Matcher: action_kit_api.LineChartWidgetGroupMatcherFallback{
+								Type: action_kit_api.ComSteadybitWidgetLineChartGroupMatcherFallback,
+							},
+						},
+					},
+				}),
+				Tooltip: new(action_kit_api.LineChartWidgetTooltipConfig{
+					MetricValueTitle: new("Value"),
+					AdditionalContent: []action_kit_api.LineChartWidgetTooltipContent{
+						{From: "slo", Title: "SLO"},
+					},
+				}),
+			},
+		}),
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: new(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: new("10s"),
+		}),
+	}
+}
+
+type SloApi interface {
+	GetSloConfigs(ctx context.Context, page int, pageSize int) (*types.SloConfigResponse, error)
+	GetSloReport(ctx context.Context, sloId string, from time.Time, to time.Time) (*types.SloReport, error)
+}
+
+func (m *SloCheckAction) Prepare(ctx context.Context, state *SloCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	return nil, PrepareSloCheck(ctx, state, request, &config.Config)
+}
+
+func PrepareSloCheck(ctx context.Context, state *SloCheckState, request action_kit_api.PrepareActionRequestBody, api SloApi) error {
+	slo := strings.TrimSpace(extutil.ToString(request.Config["slo"]))
+	if slo == "" {
+		return extension_kit.ToError("Missing SLO.", nil)
+	}
+	sloConfig, err := findSloConfig(ctx, slo, api)
+	if err != nil {
+		return extension_kit.ToError("Failed to get SLO configurations from Instana.", err)
+	}
+	if sloConfig == nil {
+		return extension_kit.ToError(fmt.Sprintf("SLO '%s' not found in Instana.", slo), nil)
+	}
+
+	duration := extutil.ToInt64(request.Config["duration"])
+	state.Start = time.Now()
+	state.End = time.Now().Add(time.Millisecond * time.Duration(duration))
+	state.SloId = sloConfig.Id
+	state.SloName = sloConfig.Name
+	state.ReportWindowInMillis = toReportWindow(sloConfig.TimeWindow).Milliseconds()
+	state.MinSli = extcommon.OptionalFloat(request.Config, "minSli")
+	if state.MinSli == nil && sloConfig.Target > 0 {
+		state.MinSli = new(sloConfig.Target * 100)
+	}
+	state.MaxErrorBudgetBurn = extcommon.OptionalFloat(request.Config, "maxErrorBudgetBurn")
+	return nil
+}
+
+func (m *SloCheckAction) Start(ctx context.Context, state *SloCheckState) (*action_kit_api.StartResult, error) {
+	statusResult, err := SloCheckStatus(ctx, state, &config.Config)
+	if statusResult == nil {
+		return nil, err
+	}
+	startResult := action_kit_api.StartResult{
+		Artifacts: statusResult.Artifacts,
+		Error:     statusResult.Error,
+		Messages:  statusResult.Messages,
+		Metrics:   statusResult.Metrics,
+	}
+	return &startResult, err
+}
+
+func (m *SloCheckAction) Status(ctx context.Context, state *SloCheckState) (*action_kit_api.StatusResult, error) {
+	return SloCheckStatus(ctx, state, &config.Config)
+}
+
+func SloCheckStatus(ctx context.Context, state *SloCheckState, api SloApi) (*action_kit_api.StatusResult, error) {
+	now := time.Now()
+	report, err := api.GetSloReport(ctx, state.SloId, now.Add(-time.Duration(state.ReportWindowInMillis)*time.Millisecond), now)
+	if err != nil {
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get report of SLO '%s' from Instana.", state.SloName), err)
+	}
+	if state.Before == nil {
+		state.Before = report
+	}
+	state.After = report
+
+	violations := make([]string, 0)
+	sli := report.Sli * 100
+	if state.MinSli != nil && sli < *state.MinSli {
+		violations = append(violations, fmt.Sprintf("SLI %.3f%% is below %.3f%%", sli, *state.MinSli))
+	}
+	burn := errorBudgetBurn(state.Before, report)
+	if state.MaxErrorBudgetBurn != nil && burn > *state.MaxErrorBudgetBurn {
+		violations = append(violations, fmt.Sprintf("burned %.2f%% of the error budget, more than %.2f%%", burn, *state.MaxErrorBudgetBurn))
+	}
+
+	completed := now.After(state.End)
+	result := action_kit_api.StatusResult{
+		Completed: completed,
+		Metrics:   new(toMetrics(state.SloName, sli, burn, len(violations) > 0, now)),
+	}
+	if len(violations) > 0 {
+		completed = true
+		result.Completed = true
+		result.Error = new(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("SLO '%s' violated: %s.", state.SloName, strings.Join(violations, ", ")),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	}
+	if completed {
+		artifact, err := errorBudgetArtifact(state)
+		if err != nil {
+			return nil, extension_kit.ToError("Failed to create error budget report.", err)
+		}
+		result.Artifacts = new([]action_kit_api.Artifact{artifact})
+	}
+	return &result, nil
+}
+
+func findSloConfig(ctx context.Context, slo string, api SloApi) (*types.SloConfig, error) {
+	for page := 1; ; page++ {
+		response, err := api.GetSloConfigs(ctx, page, sloConfigPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, sloConfig := range response.Items {
+			if sloConfig.Id == slo || sloConfig.Name == slo {
+				return &sloConfig, nil
+			}
+		}
+		if len(response.Items) < sloConfigPageSize || page*sloConfigPageSize >= response.TotalHits {
+			log.Debug().Str("slo", slo).Int("pages", page).Msg("SLO not found.")
+			return nil, nil
+		}
+	}
+}
+
+// toReportWindow returns the time window of the SLO, falling back to a week if it can't be determined.
+func toReportWindow(timeWindow types.SloTimeWindow) time.Duration {
+	units := map[string]time.Duration{
+		"minute": time.Minute,
+		"hour":   time.Hour,
+		"day":    24 * time.Hour,
+		"week":   7 * 24 * time.Hour,
+		"month":  30 * 24 * time.Hour,
+	}
+	unit, ok := units[strings.TrimSuffix(strings.ToLower(timeWindow.DurationUnit), "s")]
+	if !ok || timeWindow.Duration <= 0 {
+		return units["week"]
+	}
+	return time.Duration(timeWindow.Duration) * unit
+}
+
+// errorBudgetBurn returns the share of the total error budget in percent burned since the before report.
+func errorBudgetBurn(before *types.SloReport, after *types.SloReport) float64 {
+	if before == nil || after.TotalErrorBudget <= 0 {
+		return 0
+	}
+	return (before.ErrorBudgetRemaining - after.ErrorBudgetRemaining) / after.TotalErrorBudget * 100
+}
+
+func toMetrics(slo string, sli float64, burn float64, violated bool, now time.Time) []action_kit_api.Metric {
+	values := map[string]float64{
+		"sli":             sli,
+		"errorBudgetBurn": burn,
+	}
+	metrics := make([]action_kit_api.Metric, 0, len(values))
+	for _, metric := range []string{"sli", "errorBudgetBurn"} {
+		metrics = append(metrics, action_kit_api.Metric{
+			Name: new("instana_slo"),
+			Metric: map[string]string{
+				"metric":   metric,
+				"slo":      slo,
+				"violated": fmt.Sprintf("%t", violated),
+			},
+			Timestamp: now,
+			Value:     values[metric],
+		})
+	}
+	return metrics
+}
+
+func errorBudgetArtifact(state *SloCheckState) (action_kit_api.Artifact, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	_ = writer.Write([]string{"slo", "report", "sli (%)", "slo (%)", "total error budget", "error budget remaining", "error budget spent"})
+	for _, row := range []struct {
+		label  string
+		report *types.SloReport
+	}{{"before", state.Before}, {"after", state.After}} {
+		if row.report == nil {
+			continue
+		}
+		_ = writer.Write([]string{
+			state.SloName,
+			row.label,
+			fmt.Sprintf("%.3f", row.report.Sli*100),
+			fmt.Sprintf("%.3f", row.report.Slo*100),
+			fmt.Sprintf("%.2f", row.report.TotalErrorBudget),
+			fmt.Sprintf("%.2f", row.report.ErrorBudgetRemaining),
+			fmt.Sprintf("%.2f", row.report.ErrorBudgetSpent),
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return action_kit_api.Artifact{}, err
+	}
+
+	return action_kit_api.Artifact{
+		Label: "slo_error_budget.csv",
+		Data:  base64.StdEncoding.EncodeToString(buffer.Bytes()),
+	}, nil
+}
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extslo
+
+import (
+	"context"
+	"encoding/base64"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type instanaApiMock struct {
+	mock.Mock
+}
+
+func (m *instanaApiMock) GetSloConfigs(ctx context.Context, page int, pageSize int) (*types.SloConfigResponse, error) {
+	args := m.Called(ctx, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.SloConfigResponse), args.Error(1)
+}
+
+func (m *instanaApiMock) GetSloReport(ctx context.Context, sloId string, from time.Time, to time.Time) (*types.SloReport, error) {
+	args := m.Called(ctx, sloId, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.SloReport), args.Error(1)
+}
+
+func TestPrepareResolvesSloByName(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetSloConfigs", mock.Anything, 1, sloConfigPageSize).Return(&types.SloConfigResponse{
+		Items: []types.SloConfig{
+			{Id: "slo-1", Name: "checkout availability", Target: 0.995, TimeWindow: types.SloTimeWindow{Type: "rolling", Duration: 1, DurationUnit: "day"}},
+		},
+		TotalHits: 1,
+	}, nil)
+	state := SloCheckState{}
+
+	// When
+	err := PrepareSloCheck(context.Background(), &state, action_kit_api.PrepareActionRequestBody{
+		Config: map[string]any{"duration": 60000, "slo": "checkout availability"},
+	}, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.Equal(t, "slo-1", state.SloId)
+	require.Equal(t, (24 * time.Hour).Milliseconds(), state.ReportWindowInMillis)
+	require.InDelta(t, 99.5, *state.MinSli, 0.0001)
+	require.Nil(t, state.MaxErrorBudgetBurn)
+}
+
+func TestPrepareFailsForUnknownSlo(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetSloConfigs", mock.Anything, 1, sloConfigPageSize).Return(&types.SloConfigResponse{TotalHits: 0}, nil)
+	state := SloCheckState{}
+
+	// When
+	err := PrepareSloCheck(context.Background(), &state, action_kit_api.PrepareActionRequestBody{
+		Config: map[string]any{"duration": 60000, "slo": "unknown"},
+	}, mockedApi)
+
+	// Then
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "SLO 'unknown' not found in Instana.")
+}
+
+func TestSloCheckFailsOnExcessiveBurn(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetSloReport", mock.Anything, "slo-1", mock.Anything, mock.Anything).Return(&types.SloReport{Sli: 0.999, Slo: 0.995, TotalErrorBudget: 100, ErrorBudgetRemaining: 80}, nil).Once()
+	mockedApi.On("GetSloReport", mock.Anything, "slo-1", mock.Anything, mock.Anything).Return(&types.SloReport{Sli: 0.998, Slo: 0.995, TotalErrorBudget: 100, ErrorBudgetRemaining: 77}, nil).Once()
+	state := SloCheckState{
+		End:                  time.Now().Add(time.Minute),
+		SloId:                "slo-1",
+		SloName:              "checkout availability",
+		ReportWindowInMillis: time.Hour.Milliseconds(),
+		MinSli:               new(99.5),
+		MaxErrorBudgetBurn:   new(2.0),
+	}
+
+	// When
+	first, err := SloCheckStatus(context.Background(), &state, mockedApi)
+	require.NoError(t, err)
+	second, err := SloCheckStatus(context.Background(), &state, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.Nil(t, first.Error)
+	require.False(t, first.Completed)
+	require.NotNil(t, second.Error)
+	require.Equal(t, "SLO 'checkout availability' violated: burned 3.00% of the error budget, more than 2.00%.", second.Error.Title)
+	require.True(t, second.Completed)
+	require.Len(t, *second.Artifacts, 1)
+	data, err := base64.StdEncoding.DecodeString((*second.Artifacts)[0].Data)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "checkout availability,before,99.900,99.500,100.00,80.00,0.00")
+	require.Contains(t, string(data), "checkout availability,after,99.800,99.500,100.00,77.00,0.00")
+}
+
+func TestSloCheckFailsOnBreach(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetSloReport", mock.Anything, "slo-1", mock.Anything, mock.Anything).Return(&types.SloReport{Sli: 0.99, Slo: 0.995, TotalErrorBudget: 100}, nil)
+	state := SloCheckState{
+		End:     time.Now().Add(time.Minute),
+		SloId:   "slo-1",
+		SloName: "checkout availability",
+		MinSli:  new(99.5),
+	}
+
+	// When
+	result, err := SloCheckStatus(context.Background(), &state, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.NotNil(t, result.Error)
+	require.Equal(t, "SLO 'checkout availability' violated: SLI 99.000% is below 99.500%.", result.Error.Title)
+}
@@ -0,0 +1,309 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extsynthetics
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-instana/config"
+	"github.com/steadybit/extension-instana/extcommon"
+	"github.com/steadybit/extension-instana/types"
+	extension_kit "github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+	"sort"
+	"strings"
+	"time"
+)
+
+type SyntheticTestCheckAction struct{}
+
+// Make sure action implements all required interfaces
+var (
+	_ action_kit_sdk.Action[SyntheticTestCheckState]           = (*SyntheticTestCheckAction)(nil)
+	_ action_kit_sdk.ActionWithStatus[SyntheticTestCheckState] = (*SyntheticTestCheckAction)(nil)
+)
+
+type SyntheticTestCheckState struct {
+	Start            time.Time
+	End              time.Time
+	TestIds          []string
+	Locations        []string
+	IntervalInMillis int64
+	LastTriggered    time.Time
+	Triggered        int
+	MinAvailability  *float64
+	MaxResponseTime  *float64
+}
+
+func NewSyntheticTestCheckAction() action_kit_sdk.Action[SyntheticTestCheckState] {
+	return &SyntheticTestCheckAction{}
+}
+
+func (m *SyntheticTestCheckAction) NewEmptyState() SyntheticTestCheckState {
+	return SyntheticTestCheckState{}
+}
+
+func (m *SyntheticTestCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          SyntheticTestCheckActionId,
+		Label:       "Synthetic Test Check",
+		Description: "Runs an Instana synthetic test during the step and checks its availability and response time.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        new(syntheticTestIcon),
+		TargetSelection: new(action_kit_api.TargetSelection{
+			TargetType: SyntheticTestTargetId,
+			SelectionTemplates: new([]action_kit_api.TargetSelectionTemplate{
+				{
+					Label: "synthetic test label",
+					Query: "instana.synthetic-test.label=\"\"",
+				},
+			}),
+		}),
+		Technology:  new("Instana"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  new("How long to wait for results of the synthetic tests."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: new("2m"),
+				Order:        new(1),
+				Required:     new(true),
+			},
+			{
+				Name:        "interval",
+				Label:       "Interval",
+				Description: new("Run the tests again after this interval until the step ends. Leave empty to run the tests once."),
+				Type:        action_kit_api.ActionParameterTypeDuration,
+				Order:       new(2),
+				Required:    new(false),
+			},
+			{
+				Name:         "minAvailability",
+				Label:        "Min Availability",
+				Description:  new("Fail if the share of successful test runs drops below this value."),
+				Type:         action_kit_api.ActionParameterTypePercentage,
+				DefaultValue: new("100"),
+				Order:        new(3),
+				Required:     new(false),
+			},
+			{
+				Name:        "maxResponseTime",
+				Label:       "Max Response Time (ms)",
+				Description: new("Fail if the average response time of a test exceeds this value. Leave empty to not check the response time."),
+				Type:        action_kit_api.ActionParameterTypeInteger,
+				Order:       new(4),
+				Required:    new(false),
+			},
+			{
+				Name:        "locations",
+				Label:       "Locations",
+				Description: new("Ids of the locations to run the tests from. Leave empty to use the locations configured for the tests."),
+				Type:        action_kit_api.ActionParameterTypeStringArray,
+				Order:       new(5),
+				Required:    new(false),
+				Advanced:    new(true),
+			},
+		},
+		Widgets: new([]action_kit_api.Widget{
+			action_kit_api.StateOverTimeWidget{
+				Type:  action_kit_api.ComSteadybitWidgetStateOverTime,
+				Title: "Instana Synthetic Tests",
+				Identity: action_kit_api.StateOverTimeWidgetIdentityConfig{
+					From: "id",
+				},
+				Label: action_kit_api.StateOverTimeWidgetLabelConfig{
+					From: "title",
+				},
+				State: action_kit_api.StateOverTimeWidgetStateConfig{
+					From: "state",
+				},
+				Tooltip: action_kit_api.StateOverTimeWidgetTooltipConfig{
+					From: "tooltip",
+				},
+				Url: new(action_kit_api.StateOverTimeWidgetUrlConfig{
+					From: new("url"),
+				}),
+				Value: new(action_kit_api.StateOverTimeWidgetValueConfig{
+					Hide: new(true),
+				}),
+			},
+		}),
+		Prepare: action_kit_api.MutatingEndpointReference{},
+		Start:   action_kit_api.MutatingEndpointReference{},
+		Status: new(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: new("10s"),
+		}),
+	}
+}
+
+func (m *SyntheticTestCheckAction) Prepare(_ context.Context, state *SyntheticTestCheckState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	duration := extutil.ToInt64(request.Config["duration"])
+	state.Start = time.Now()
+	state.End = time.Now().Add(time.Millisecond * time.Duration(duration))
+
+	testIds := request.Target.Attributes["instana.synthetic-test.id"]
+	if len(testIds) == 0 {
+		return nil, extension_kit.ToError("Target is missing the 'instana.synthetic-test.id' attribute.", nil)
+	}
+	state.TestIds = testIds
+	if request.Config["locations"] != nil {
+		state.Locations = extutil.ToStringArray(request.Config["locations"])
+	}
+	state.IntervalInMillis = extutil.ToInt64(request.Config["interval"])
+	state.MinAvailability = extcommon.OptionalFloat(request.Config, "minAvailability")
+	state.MaxResponseTime = extcommon.OptionalFloat(request.Config, "maxResponseTime")
+	return nil, nil
+}
+
+func (m *SyntheticTestCheckAction) Start(ctx context.Context, state *SyntheticTestCheckState) (*action_kit_api.StartResult, error) {
+	if err := triggerTests(ctx, state, &config.Config, time.Now()); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (m *SyntheticTestCheckAction) Status(ctx context.Context, state *SyntheticTestCheckState) (*action_kit_api.StatusResult, error) {
+	return SyntheticTestCheckStatus(ctx, state, &config.Config)
+}
+
+type SyntheticsApi interface {
+	TriggerSyntheticTests(ctx context.Context, runs []types.SyntheticTestRunRequest) ([]types.SyntheticTestRun, error)
+	GetSyntheticResults(ctx context.Context, request types.SyntheticResultsRequest) (*types.SyntheticResultsResponse, error)
+}
+
+func triggerTests(ctx context.Context, state *SyntheticTestCheckState, api SyntheticsApi, now time.Time) error {
+	runs := make([]types.SyntheticTestRunRequest, 0, len(state.TestIds))
+	for _, testId := range state.TestIds {
+		runs = append(runs, types.SyntheticTestRunRequest{TestId: testId, Locations: state.Locations})
+	}
+	triggered, err := api.TriggerSyntheticTests(ctx, runs)
+	if err != nil {
+		return extension_kit.ToError("Failed to trigger synthetic tests in Instana.", err)
+	}
+	log.Debug().Int("count", len(triggered)).Strs("testIds", state.TestIds).Msg("Triggered synthetic tests.")
+	state.LastTriggered = now
+	state.Triggered++
+	return nil
+}
+
+func SyntheticTestCheckStatus(ctx context.Context, state *SyntheticTestCheckState, api SyntheticsApi) (*action_kit_api.StatusResult, error) {
+	now := time.Now()
+	completed := now.After(state.End)
+	if !completed && state.IntervalInMillis > 0 && now.Sub(state.LastTriggered).Milliseconds() >= state.IntervalInMillis {
+		if err := triggerTests(ctx, state, api, now); err != nil {
+			return nil, err
+		}
+	}
+
+	response, err := api.GetSyntheticResults(ctx, types.SyntheticResultsRequest{
+		TestIds: state.TestIds,
+		Metrics: []types.SyntheticMetricConfig{{Metric: metricStatus}, {Metric: metricResponseTime}},
+		TimeFrame: types.TimeFrame{
+			To:         now.UnixMilli(),
+			WindowSize: now.Sub(state.Start).Milliseconds(),
+		},
+	})
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to get synthetic test results from Instana.", err)
+	}
+
+	violations := make([]string, 0)
+	metrics := make([]action_kit_api.Metric, 0, len(response.Items))
+	for _, item := range response.Items {
+		runs := len(item.Metrics[metricStatus])
+		if runs == 0 {
+			continue
+		}
+		availability := float64(successfulRuns(item.Metrics[metricStatus])) / float64(runs) * 100
+		responseTime := average(item.Metrics[metricResponseTime])
+
+		itemViolations := make([]string, 0)
+		if state.MinAvailability != nil && availability < *state.MinAvailability {
+			itemViolations = append(itemViolations, fmt.Sprintf("availability %.1f%% is below %.1f%%", availability, *state.MinAvailability))
+		}
+		if state.MaxResponseTime != nil && responseTime != nil && *responseTime > *state.MaxResponseTime {
+			itemViolations = append(itemViolations, fmt.Sprintf("response time %.0fms exceeds %.0fms", *responseTime, *state.MaxResponseTime))
+		}
+		for _, violation := range itemViolations {
+			violations = append(violations, fmt.Sprintf("%s (%s): %s", item.TestName, item.LocationLabel, violation))
+		}
+		metrics = append(metrics, toMetric(item, runs, availability, responseTime, len(itemViolations) > 0, now))
+	}
+	sort.Slice(metrics, func(i, j int) bool {
+		return metrics[i].Metric["id"] < metrics[j].Metric["id"]
+	})
+
+	result := action_kit_api.StatusResult{
+		Completed: completed,
+		Metrics:   new(metrics),
+	}
+	if len(violations) > 0 {
+		result.Completed = true
+		result.Error = new(action_kit_api.ActionKitError{
+			Title:  fmt.Sprintf("Synthetic tests failed: %s.", strings.Join(violations, ", ")),
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	} else if completed && len(metrics) == 0 {
+		result.Error = new(action_kit_api.ActionKitError{
+			Title:  "No results of the synthetic tests were reported by Instana during the step.",
+			Status: extutil.Ptr(action_kit_api.Failed),
+		})
+	}
+	return &result, nil
+}
+
+func toMetric(item types.SyntheticResult, runs int, availability float64, responseTime *float64, violated bool, now time.Time) action_kit_api.Metric {
+	state := "success"
+	if violated {
+		state = "danger"
+	}
+	tooltip := fmt.Sprintf("Test: %s\nLocation: %s\nRuns: %d\nAvailability: %.1f%%", item.TestName, item.LocationLabel, runs, availability)
+	if responseTime != nil {
+		tooltip += fmt.Sprintf("\nAverage Response Time: %.0fms", *responseTime)
+	}
+	return action_kit_api.Metric{
+		Name: new("instana_synthetic_tests"),
+		Metric: map[string]string{
+			"id":      item.TestId + "-" + item.LocationId,
+			"title":   fmt.Sprintf("%s (%s)", item.TestName, item.LocationLabel),
+			"state":   state,
+			"tooltip": tooltip,
+			"url":     fmt.Sprintf("%s/#/synthetics/testDetails;testId=%s", config.Config.BaseUrl, item.TestId),
+		},
+		Timestamp: now,
+		Value:     availability,
+	}
+}
+
+func successfulRuns(points [][]float64) int {
+	successful := 0
+	for _, point := range points {
+		if len(point) >= 2 && point[1] == 1 {
+			successful++
+		}
+	}
+	return successful
+}
+
+func average(points [][]float64) *float64 {
+	sum := 0.0
+	count := 0
+	for _, point := range points {
+		if len(point) >= 2 {
+			sum += point[1]
+			count++
+		}
+	}
+	if count == 0 {
+		return nil
+	}
+	return new(sum / float64(count))
+}
@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extsynthetics
+
+import (
+	"context"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type instanaApiMock struct {
+	mock.Mock
+}
+
+func (m *instanaApiMock) TriggerSyntheticTests(ctx context.Context, runs []types.SyntheticTestRunRequest) ([]types.SyntheticTestRun, error) {
+	args := m.Called(ctx, runs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]types.SyntheticTestRun), args.Error(1)
+}
+
+func (m *instanaApiMock) GetSyntheticResults(ctx context.Context, request types.SyntheticResultsRequest) (*types.SyntheticResultsResponse, error) {
+	args := m.Called(ctx, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.SyntheticResultsResponse), args.Error(1)
+}
+
+func syntheticResult(testId string, statuses []float64, responseTimes []float64) types.SyntheticResult {
+	result := types.SyntheticResult{
+		TestId:        testId,
+		TestName:      testId + " test",
+		LocationId:    "loc-1",
+		LocationLabel: "Frankfurt",
+		Metrics:       map[string][][]float64{},
+	}
+	for i, status := range statuses {
+		result.Metrics[metricStatus] = append(result.Metrics[metricStatus], []float64{float64(1700000000000 + i), status})
+	}
+	for i, responseTime := range responseTimes {
+		result.Metrics[metricResponseTime] = append(result.Metrics[metricResponseTime], []float64{float64(1700000000000 + i), responseTime})
+	}
+	return result
+}
+
+func TestSyntheticTestsPass(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetSyntheticResults", mock.Anything, mock.Anything).Return(&types.SyntheticResultsResponse{
+		Items: []types.SyntheticResult{syntheticResult("checkout", []float64{1, 1}, []float64{200, 300})},
+	}, nil)
+	state := SyntheticTestCheckState{
+		Start:           time.Now().Add(-time.Minute),
+		End:             time.Now().Add(time.Minute),
+		TestIds:         []string{"checkout"},
+		LastTriggered:   time.Now(),
+		MinAvailability: new(100.0),
+		MaxResponseTime: new(500.0),
+	}
+
+	// When
+	result, err := SyntheticTestCheckStatus(context.Background(), &state, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.Nil(t, result.Error)
+	require.False(t, result.Completed)
+	require.Len(t, *result.Metrics, 1)
+	metric := (*result.Metrics)[0]
+	require.Equal(t, "success", metric.Metric["state"])
+	require.Equal(t, "checkout test (Frankfurt)", metric.Metric["title"])
+	require.Contains(t, metric.Metric["tooltip"], "Average Response Time: 250ms")
+	require.Contains(t, metric.Metric["url"], "/#/synthetics/testDetails;testId=checkout")
+	mockedApi.AssertNotCalled(t, "TriggerSyntheticTests", mock.Anything, mock.Anything)
+}
+
+func TestSyntheticTestsFailOnThresholds(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetSyntheticResults", mock.Anything, mock.Anything).Return(&types.SyntheticResultsResponse{
+		Items: []types.SyntheticResult{syntheticResult("checkout", []float64{1, 0}, []float64{900, 1100})},
+	}, nil)
+	state := SyntheticTestCheckState{
+		Start:           time.Now().Add(-time.Minute),
+		End:             time.Now().Add(time.Minute),
+		TestIds:         []string{"checkout"},
+		LastTriggered:   time.Now(),
+		MinAvailability: new(100.0),
+		MaxResponseTime: new(500.0),
+	}
+
+	// When
+	result, err := SyntheticTestCheckStatus(context.Background(), &state, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.True(t, result.Completed)
+	require.NotNil(t, result.Error)
+	require.Equal(t, "Synthetic tests failed: checkout test (Frankfurt): availability 50.0% is below 100.0%, checkout test (Frankfurt): response time 1000ms exceeds 500ms.", result.Error.Title)
+	require.Equal(t, "danger", (*result.Metrics)[0].Metric["state"])
+}
+
+func TestSyntheticTestsAreTriggeredAgainAfterInterval(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("TriggerSyntheticTests", mock.Anything, []types.SyntheticTestRunRequest{{TestId: "checkout", Locations: []string{"loc-1"}}}).Return([]types.SyntheticTestRun{{TestId: "checkout", TestResultId: "run-2"}}, nil)
+	mockedApi.On("GetSyntheticResults", mock.Anything, mock.Anything).Return(&types.SyntheticResultsResponse{}, nil)
+	state := SyntheticTestCheckState{
+		Start:            time.Now().Add(-time.Minute),
+		End:              time.Now().Add(time.Minute),
+		TestIds:          []string{"checkout"},
+		Locations:        []string{"loc-1"},
+		IntervalInMillis: 30000,
+		LastTriggered:    time.Now().Add(-31 * time.Second),
+		Triggered:        1,
+	}
+
+	// When
+	result, err := SyntheticTestCheckStatus(context.Background(), &state, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.Nil(t, result.Error)
+	require.Equal(t, 2, state.Triggered)
+	mockedApi.AssertNumberOfCalls(t, "TriggerSyntheticTests", 1)
+}
+
+func TestSyntheticTestsFailWithoutResults(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetSyntheticResults", mock.Anything, mock.Anything).Return(&types.SyntheticResultsResponse{}, nil)
+	state := SyntheticTestCheckState{
+		Start:   time.Now().Add(-time.Minute),
+		End:     time.Now().Add(-time.Second),
+		TestIds: []string{"checkout"},
+	}
+
+	// When
+	result, err := SyntheticTestCheckStatus(context.Background(), &state, mockedApi)
+
+	// Then
+	require.NoError(t, err)
+	require.True(t, result.Completed)
+	require.NotNil(t, result.Error)
+	require.Equal(t, "No results of the synthetic tests were reported by Instana during the step.", result.Error.Title)
+}
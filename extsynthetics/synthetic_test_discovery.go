@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extsynthetics
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
+	"github.com/steadybit/extension-instana/config"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/steadybit/extension-kit/extbuild"
+	"time"
+)
+
+type syntheticTestDiscovery struct {
+}
+
+var (
+	_ discovery_kit_sdk.TargetDescriber    = (*syntheticTestDiscovery)(nil)
+	_ discovery_kit_sdk.AttributeDescriber = (*syntheticTestDiscovery)(nil)
+)
+
+func NewSyntheticTestDiscovery() discovery_kit_sdk.TargetDiscovery {
+	discovery := &syntheticTestDiscovery{}
+	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
+		discovery_kit_sdk.WithRefreshTargetsNow(),
+		discovery_kit_sdk.WithRefreshTargetsInterval(context.Background(), 1*time.Minute),
+	)
+}
+
+func (d *syntheticTestDiscovery) Describe() discovery_kit_api.DiscoveryDescription {
+	return discovery_kit_api.DiscoveryDescription{
+		Id: SyntheticTestTargetId,
+		Discover: discovery_kit_api.DescribingEndpointReferenceWithCallInterval{
+			CallInterval: new("1m"),
+		},
+	}
+}
+
+func (d *syntheticTestDiscovery) DescribeTarget() discovery_kit_api.TargetDescription {
+	return discovery_kit_api.TargetDescription{
+		Id:       SyntheticTestTargetId,
+		Label:    discovery_kit_api.PluralLabel{One: "Instana Synthetic Test", Other: "Instana Synthetic Tests"},
+		Category: new("monitoring"),
+		Version:  extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:     new(syntheticTestIcon),
+		Table: discovery_kit_api.Table{
+			Columns: []discovery_kit_api.Column{
+				{Attribute: "steadybit.label"},
+				{Attribute: "instana.synthetic-test.type"},
+				{Attribute: "instana.synthetic-test.active"},
+			},
+			OrderBy: []discovery_kit_api.OrderBy{
+				{
+					Attribute: "steadybit.label",
+					Direction: "ASC",
+				},
+			},
+		},
+	}
+}
+
+func (d *syntheticTestDiscovery) DescribeAttributes() []discovery_kit_api.AttributeDescription {
+	return []discovery_kit_api.AttributeDescription{
+		{
+			Attribute: "instana.synthetic-test.id",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana synthetic test id",
+				Other: "Instana synthetic test ids",
+			},
+		},
+		{
+			Attribute: "instana.synthetic-test.label",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana synthetic test label",
+				Other: "Instana synthetic test labels",
+			},
+		},
+		{
+			Attribute: "instana.synthetic-test.type",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana synthetic test type",
+				Other: "Instana synthetic test types",
+			},
+		},
+		{
+			Attribute: "instana.synthetic-test.location",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana synthetic test location",
+				Other: "Instana synthetic test locations",
+			},
+		},
+		{
+			Attribute: "instana.synthetic-test.url",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana synthetic test URL",
+				Other: "Instana synthetic test URLs",
+			},
+		},
+		{
+			Attribute: "instana.synthetic-test.active",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana synthetic test active state",
+				Other: "Instana synthetic test active states",
+			},
+		},
+		{
+			Attribute: "instana.application.id",
+			Label: discovery_kit_api.PluralLabel{
+				One:   "Instana application perspective id",
+				Other: "Instana application perspective ids",
+			},
+		},
+	}
+}
+
+func (d *syntheticTestDiscovery) DiscoverTargets(ctx context.Context) ([]discovery_kit_api.Target, error) {
+	return getAllSyntheticTests(ctx, &config.Config), nil
+}
+
+type GetSyntheticTestsApi interface {
+	GetSyntheticTests(ctx context.Context) ([]types.SyntheticTest, error)
+}
+
+func getAllSyntheticTests(ctx context.Context, api GetSyntheticTestsApi) []discovery_kit_api.Target {
+	start := time.Now()
+	tests, err := api.GetSyntheticTests(ctx)
+	if err != nil {
+		log.Err(err).Msg("Failed to get synthetic tests from Instana.")
+		return []discovery_kit_api.Target{}
+	}
+
+	result := make([]discovery_kit_api.Target, 0, len(tests))
+	for _, test := range tests {
+		result = append(result, toTarget(test))
+	}
+	log.Debug().Msgf("Discovery took %s, returning %d synthetic tests.", time.Since(start), len(result))
+	return result
+}
+
+func toTarget(test types.SyntheticTest) discovery_kit_api.Target {
+	attributes := make(map[string][]string)
+	attributes["steadybit.label"] = []string{test.Label}
+	attributes["instana.synthetic-test.id"] = []string{test.Id}
+	attributes["instana.synthetic-test.label"] = []string{test.Label}
+	attributes["instana.synthetic-test.active"] = []string{fmt.Sprintf("%t", test.Active)}
+	if test.Configuration.SyntheticType != "" {
+		attributes["instana.synthetic-test.type"] = []string{test.Configuration.SyntheticType}
+	}
+	if test.Configuration.Url != "" {
+		attributes["instana.synthetic-test.url"] = []string{test.Configuration.Url}
+	}
+	if len(test.Locations) > 0 {
+		attributes["instana.synthetic-test.location"] = test.Locations
+	}
+
+	applicationIds := test.ApplicationIds
+	if len(applicationIds) == 0 && test.ApplicationId != "" {
+		applicationIds = []string{test.ApplicationId}
+	}
+	if len(applicationIds) > 0 {
+		attributes["instana.application.id"] = applicationIds
+	}
+
+	return discovery_kit_api.Target{
+		Id:         test.Id,
+		Label:      test.Label,
+		TargetType: SyntheticTestTargetId,
+		Attributes: attributes,
+	}
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extsynthetics
+
+import (
+	"context"
+	"errors"
+	"github.com/steadybit/extension-instana/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func (m *instanaApiMock) GetSyntheticTests(ctx context.Context) ([]types.SyntheticTest, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]types.SyntheticTest), args.Error(1)
+}
+
+func TestSyntheticTestsAreDiscovered(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetSyntheticTests", mock.Anything).Return([]types.SyntheticTest{
+		{
+			Id:            "test-1",
+			Label:         "checkout api",
+			Active:        true,
+			ApplicationId: "app-1",
+			Locations:     []string{"loc-1", "loc-2"},
+			Configuration: types.SyntheticTestConfiguration{SyntheticType: "HTTPAction", Url: "https://shop.example.com/checkout"},
+		},
+		{
+			Id:            "test-2",
+			Label:         "landing page",
+			Configuration: types.SyntheticTestConfiguration{SyntheticType: "BrowserScript"},
+		},
+	}, nil)
+
+	// When
+	targets := getAllSyntheticTests(context.Background(), mockedApi)
+
+	// Then
+	require.Len(t, targets, 2)
+	require.Equal(t, "test-1", targets[0].Id)
+	require.Equal(t, SyntheticTestTargetId, targets[0].TargetType)
+	require.Equal(t, []string{"checkout api"}, targets[0].Attributes["steadybit.label"])
+	require.Equal(t, []string{"HTTPAction"}, targets[0].Attributes["instana.synthetic-test.type"])
+	require.Equal(t, []string{"loc-1", "loc-2"}, targets[0].Attributes["instana.synthetic-test.location"])
+	require.Equal(t, []string{"https://shop.example.com/checkout"}, targets[0].Attributes["instana.synthetic-test.url"])
+	require.Equal(t, []string{"app-1"}, targets[0].Attributes["instana.application.id"])
+	require.Equal(t, []string{"true"}, targets[0].Attributes["instana.synthetic-test.active"])
+	require.Equal(t, []string{"false"}, targets[1].Attributes["instana.synthetic-test.active"])
+	require.NotContains(t, targets[1].Attributes, "instana.synthetic-test.url")
+	require.NotContains(t, targets[1].Attributes, "instana.application.id")
+}
+
+func TestSyntheticTestDiscoveryReturnsEmptyResultOnError(t *testing.T) {
+	// Given
+	mockedApi := new(instanaApiMock)
+	mockedApi.On("GetSyntheticTests", mock.Anything).Return(nil, errors.New("oops"))
+
+	// When
+	targets := getAllSyntheticTests(context.Background(), mockedApi)
+
+	// Then
+	require.Empty(t, targets)
+}
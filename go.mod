@@ -12,6 +12,8 @@ require (
 	github.com/steadybit/discovery-kit/go/discovery_kit_sdk v1.4.2
 	github.com/steadybit/discovery-kit/go/discovery_kit_test v1.2.1
 	github.com/steadybit/extension-kit v1.11.2
+	github.com/steadybit/preflight-kit/go/preflight_kit_api v1.2.0
+	github.com/steadybit/preflight-kit/go/preflight_kit_sdk v1.2.0
 	github.com/stretchr/testify v1.11.1
 )
 
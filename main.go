@@ -5,21 +5,32 @@
 package main
 
 import (
+	"context"
 	"github.com/rs/zerolog"
 	"github.com/steadybit/action-kit/go/action_kit_api/v2"
 	"github.com/steadybit/action-kit/go/action_kit_sdk"
 	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
 	"github.com/steadybit/discovery-kit/go/discovery_kit_sdk"
 	"github.com/steadybit/extension-instana/config"
+	"github.com/steadybit/extension-instana/extalerts"
 	"github.com/steadybit/extension-instana/extapplications"
+	"github.com/steadybit/extension-instana/exteum"
 	"github.com/steadybit/extension-instana/extevents"
+	"github.com/steadybit/extension-instana/extinfrastructure"
 	"github.com/steadybit/extension-instana/extmaintenance"
+	"github.com/steadybit/extension-instana/extmetrics"
+	"github.com/steadybit/extension-instana/extpreflight"
+	"github.com/steadybit/extension-instana/extservices"
+	"github.com/steadybit/extension-instana/extslo"
+	"github.com/steadybit/extension-instana/extsynthetics"
 	"github.com/steadybit/extension-kit/extbuild"
 	"github.com/steadybit/extension-kit/exthealth"
 	"github.com/steadybit/extension-kit/exthttp"
 	"github.com/steadybit/extension-kit/extlogging"
 	"github.com/steadybit/extension-kit/extruntime"
 	"github.com/steadybit/extension-kit/extsignals"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_api"
+	"github.com/steadybit/preflight-kit/go/preflight_kit_sdk"
 )
 
 func main() {
@@ -32,8 +43,30 @@ func main() {
 	exthealth.StartProbes(8091)
 
 	discovery_kit_sdk.Register(extapplications.NewApplicationPerspectiveDiscovery())
+	discovery_kit_sdk.Register(extapplications.NewApplicationEnrichmentDataDiscovery())
+	discovery_kit_sdk.Register(extservices.NewServiceDiscovery())
+	discovery_kit_sdk.Register(extservices.NewEndpointDiscovery())
+	discovery_kit_sdk.Register(extsynthetics.NewSyntheticTestDiscovery())
+	discovery_kit_sdk.Register(exteum.NewWebsiteDiscovery())
+	discovery_kit_sdk.Register(exteum.NewMobileAppDiscovery())
+	discovery_kit_sdk.Register(extinfrastructure.NewInfrastructureDiscovery())
 	action_kit_sdk.RegisterAction(extevents.NewEventCheckAction())
 	action_kit_sdk.RegisterAction(extmaintenance.NewCreateMaintenanceWindowAction())
+	action_kit_sdk.RegisterAction(extmaintenance.NewCreateServiceMaintenanceWindowAction())
+	action_kit_sdk.RegisterAction(extmaintenance.NewCreateEndpointMaintenanceWindowAction())
+	action_kit_sdk.RegisterAction(extmaintenance.NewCreateWebsiteMaintenanceWindowAction())
+	action_kit_sdk.RegisterAction(extmaintenance.NewCreateMobileAppMaintenanceWindowAction())
+	action_kit_sdk.RegisterAction(extalerts.NewDisableAlertsAction())
+	action_kit_sdk.RegisterAction(extalerts.NewAlertFiringCheckAction())
+	action_kit_sdk.RegisterAction(extmetrics.NewApplicationMetricsCheckAction())
+	action_kit_sdk.RegisterAction(extmetrics.NewServiceMetricsCheckAction())
+	action_kit_sdk.RegisterAction(extmetrics.NewEndpointMetricsCheckAction())
+	action_kit_sdk.RegisterAction(extmetrics.NewInfrastructureMetricsCheckAction())
+	action_kit_sdk.RegisterAction(extslo.NewSloCheckAction())
+	action_kit_sdk.RegisterAction(extsynthetics.NewSyntheticTestCheckAction())
+	action_kit_sdk.RegisterAction(exteum.NewWebsiteCheckAction())
+	preflight_kit_sdk.RegisterPreflight(extpreflight.NewOpenEventsPreflight())
+	extmaintenance.StartMaintenanceWindowReaper(context.Background())
 	//extevents.RegisterEventListenerHandlers()
 
 	exthttp.RegisterRevisionedHandler("/", getExtensionList)
@@ -48,16 +81,18 @@ func main() {
 }
 
 // ExtensionListResponse exists to merge the possible root path responses supported by the
-// various extension kits. In this case, the response for ActionKit, DiscoveryKit and EventKit.
+// various extension kits. In this case, the response for ActionKit, DiscoveryKit, PreflightKit and EventKit.
 type ExtensionListResponse struct {
 	action_kit_api.ActionList       `json:",inline"`
 	discovery_kit_api.DiscoveryList `json:",inline"`
+	preflight_kit_api.PreflightList `json:",inline"`
 }
 
 func getExtensionList() ExtensionListResponse {
 	return ExtensionListResponse{
 		ActionList:    action_kit_sdk.GetActionList(),
 		DiscoveryList: discovery_kit_sdk.GetDiscoveryList(),
+		PreflightList: preflight_kit_sdk.GetPreflightList(),
 		//EventListenerList: extevents.GetEventListenerList(),
 	}
 }
@@ -1,18 +1,19 @@
 package types
 
 type Event struct {
-	EventId     string `json:"eventId"`
-	Start       int64  `json:"start"`
-	End         int64  `json:"end"`
-	Type        string `json:"type"`
-	State       string `json:"state"`
-	Problem     string `json:"problem"`
-	Detail      string `json:"detail"`
-	Severity    int    `json:"severity"`
-	EntityName  string `json:"entityName"`
-	EntityLabel string `json:"entityLabel"`
-	EntityType  string `json:"entityType"`
-	SnapshotId  string `json:"snapshotId"`
+	EventId              string `json:"eventId"`
+	Start                int64  `json:"start"`
+	End                  int64  `json:"end"`
+	Type                 string `json:"type"`
+	State                string `json:"state"`
+	Problem              string `json:"problem"`
+	Detail               string `json:"detail"`
+	Severity             int    `json:"severity"`
+	EntityName           string `json:"entityName"`
+	EntityLabel          string `json:"entityLabel"`
+	EntityType           string `json:"entityType"`
+	SnapshotId           string `json:"snapshotId"`
+	EventSpecificationId string `json:"eventSpecificationId,omitempty"`
 }
 
 type ApplicationPerspective struct {
@@ -20,9 +21,19 @@ type ApplicationPerspective struct {
 	Label string `json:"label"`
 }
 
+// ApplicationConfig is the configuration of an application perspective, defining which calls and services belong to it.
+type ApplicationConfig struct {
+	Id                  string     `json:"id"`
+	Label               string     `json:"label"`
+	Scope               string     `json:"scope"`
+	BoundaryScope       string     `json:"boundaryScope"`
+	TagFilterExpression *TagFilter `json:"tagFilterExpression"`
+}
+
 type ApplicationPerspectiveResponse struct {
-	Items []ApplicationPerspective `json:"items"`
-	Links map[string]string        `json:"_links"`
+	Items     []ApplicationPerspective `json:"items"`
+	Links     map[string]string        `json:"_links"`
+	TotalHits int                      `json:"totalHits"`
 }
 
 type SnapshotSearchResponse struct {
@@ -31,6 +42,27 @@ type SnapshotSearchResponse struct {
 
 type Snapshot struct {
 	SnapshotId string `json:"snapshotId"`
+	Plugin     string `json:"plugin"`
+	Label      string `json:"label"`
+	Host       string `json:"host"`
+}
+
+type SnapshotDetailsRequest struct {
+	SnapshotIds []string `json:"snapshotIds"`
+}
+
+type SnapshotDetailsResponse struct {
+	Items []SnapshotDetails `json:"items"`
+}
+
+// SnapshotDetails holds the plugin specific metadata of an infrastructure entity, e.g. name and namespace of a Kubernetes deployment.
+type SnapshotDetails struct {
+	SnapshotId string         `json:"snapshotId"`
+	Plugin     string         `json:"plugin"`
+	Label      string         `json:"label"`
+	Host       string         `json:"host"`
+	Tags       []string       `json:"tags"`
+	Data       map[string]any `json:"data"`
 }
 
 type CreateMaintenanceWindowRequest struct {
@@ -40,6 +72,14 @@ type CreateMaintenanceWindowRequest struct {
 	Scheduling Schedule `json:"scheduling"`
 }
 
+// MaintenanceWindow is a maintenance configuration as listed by Instana.
+type MaintenanceWindow struct {
+	Id         string   `json:"id"`
+	Name       string   `json:"name"`
+	Query      string   `json:"query"`
+	Scheduling Schedule `json:"scheduling"`
+}
+
 type Schedule struct {
 	Duration Duration `json:"duration"`
 	Start    int64    `json:"start"`
@@ -49,3 +89,215 @@ type Duration struct {
 	Amount int64  `json:"amount"`
 	Unit   string `json:"unit"`
 }
+
+type MetricsRequest struct {
+	ApplicationBoundaryScope string         `json:"applicationBoundaryScope,omitempty"`
+	ApplicationId            string         `json:"applicationId,omitempty"`
+	ServiceId                string         `json:"serviceId,omitempty"`
+	NameFilter               string         `json:"nameFilter,omitempty"`
+	Metrics                  []MetricConfig `json:"metrics"`
+	TimeFrame                TimeFrame      `json:"timeFrame"`
+	Pagination               *Pagination    `json:"pagination,omitempty"`
+}
+
+type Pagination struct {
+	Page     int `json:"page"`
+	PageSize int `json:"pageSize"`
+}
+
+type MetricConfig struct {
+	Metric      string `json:"metric"`
+	Aggregation string `json:"aggregation"`
+	Granularity int    `json:"granularity,omitempty"`
+}
+
+type TimeFrame struct {
+	To         int64 `json:"to"`
+	WindowSize int64 `json:"windowSize"`
+}
+
+type MetricsResponse struct {
+	Items     []MetricsItem `json:"items"`
+	Page      int           `json:"page"`
+	PageSize  int           `json:"pageSize"`
+	TotalHits int           `json:"totalHits"`
+}
+
+// MetricsItem holds the requested metrics of a single entity. The metrics are keyed by `<metric>.<aggregation>`, e.g. `latency.p99`,
+// and contain a list of [timestamp, value] data points.
+type MetricsItem struct {
+	Application *ApplicationPerspective `json:"application,omitempty"`
+	Service     *Service                `json:"service,omitempty"`
+	Endpoint    *Endpoint               `json:"endpoint,omitempty"`
+	Metrics     map[string][][]float64  `json:"metrics"`
+}
+
+type Endpoint struct {
+	Id        string `json:"id"`
+	Label     string `json:"label"`
+	Type      string `json:"type"`
+	ServiceId string `json:"serviceId"`
+	Synthetic bool   `json:"synthetic"`
+}
+
+type Service struct {
+	Id           string   `json:"id"`
+	Label        string   `json:"label"`
+	Types        []string `json:"types"`
+	Technologies []string `json:"technologies"`
+}
+
+type InfrastructureMetricsRequest struct {
+	Plugin    string    `json:"plugin"`
+	Query     string    `json:"query,omitempty"`
+	Metrics   []string  `json:"metrics"`
+	Rollup    int       `json:"rollup"`
+	TimeFrame TimeFrame `json:"timeFrame"`
+}
+
+type InfrastructureMetricsResponse struct {
+	Items []InfrastructureMetricsItem `json:"items"`
+}
+
+type InfrastructureMetricsItem struct {
+	SnapshotId string                 `json:"snapshotId"`
+	Plugin     string                 `json:"plugin"`
+	Label      string                 `json:"label"`
+	Host       string                 `json:"host"`
+	Metrics    map[string][][]float64 `json:"metrics"`
+}
+
+type SloConfigResponse struct {
+	Items     []SloConfig `json:"items"`
+	Page      int         `json:"page"`
+	PageSize  int         `json:"pageSize"`
+	TotalHits int         `json:"totalHits"`
+}
+
+type SloConfig struct {
+	Id         string        `json:"id"`
+	Name       string        `json:"name"`
+	Target     float64       `json:"target"`
+	TimeWindow SloTimeWindow `json:"timeWindow"`
+}
+
+type SloTimeWindow struct {
+	Type         string `json:"type"`
+	Duration     int    `json:"duration"`
+	DurationUnit string `json:"durationUnit"`
+}
+
+// SloReport holds the service level indicator and error budget of a SLO. Sli and Slo are ratios between 0 and 1.
+type SloReport struct {
+	Sli                  float64 `json:"sli"`
+	Slo                  float64 `json:"slo"`
+	TotalErrorBudget     float64 `json:"totalErrorBudget"`
+	ErrorBudgetRemaining float64 `json:"errorBudgetRemaining"`
+	ErrorBudgetSpent     float64 `json:"errorBudgetSpent"`
+}
+
+type SyntheticTestRunRequest struct {
+	TestId    string   `json:"testId"`
+	Locations []string `json:"locations,omitempty"`
+}
+
+type SyntheticTestRun struct {
+	TestId       string `json:"testId"`
+	TestResultId string `json:"testResultId"`
+}
+
+type SyntheticResultsRequest struct {
+	TestIds   []string                `json:"testId"`
+	Metrics   []SyntheticMetricConfig `json:"metrics"`
+	TimeFrame TimeFrame               `json:"timeFrame"`
+}
+
+type SyntheticMetricConfig struct {
+	Metric string `json:"metric"`
+}
+
+type SyntheticResultsResponse struct {
+	Items []SyntheticResult `json:"items"`
+}
+
+// SyntheticResult holds the results of a synthetic test at one location. Each data point of a metric belongs to one test run.
+type SyntheticResult struct {
+	TestId        string                 `json:"testId"`
+	TestName      string                 `json:"testName"`
+	LocationId    string                 `json:"locationId"`
+	LocationLabel string                 `json:"locationLabel"`
+	Metrics       map[string][][]float64 `json:"metrics"`
+}
+
+type SyntheticTest struct {
+	Id             string                     `json:"id"`
+	Label          string                     `json:"label"`
+	Active         bool                       `json:"active"`
+	ApplicationId  string                     `json:"applicationId"`
+	ApplicationIds []string                   `json:"applicationIds"`
+	Locations      []string                   `json:"locations"`
+	Configuration  SyntheticTestConfiguration `json:"configuration"`
+}
+
+type SyntheticTestConfiguration struct {
+	SyntheticType string `json:"syntheticType"`
+	Url           string `json:"url"`
+}
+
+type WebsiteMetricsRequest struct {
+	Type                string         `json:"type"`
+	Metrics             []MetricConfig `json:"metrics"`
+	TagFilterExpression TagFilter      `json:"tagFilterExpression"`
+	TimeFrame           TimeFrame      `json:"timeFrame"`
+}
+
+// TagFilter is either a single tag filter (type TAG_FILTER) or a combination of filters (type EXPRESSION).
+type TagFilter struct {
+	Type            string      `json:"type"`
+	Name            string      `json:"name,omitempty"`
+	Operator        string      `json:"operator,omitempty"`
+	Entity          string      `json:"entity,omitempty"`
+	Value           any         `json:"value,omitempty"`
+	LogicalOperator string      `json:"logicalOperator,omitempty"`
+	Elements        []TagFilter `json:"elements,omitempty"`
+}
+
+// WebsiteMetricsResponse holds the requested beacon metrics keyed by `<metric>.<aggregation>`, e.g. `pageLoadTime.mean`.
+type WebsiteMetricsResponse struct {
+	Metrics map[string][][]float64 `json:"metrics"`
+}
+
+type Website struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type MobileApp struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	Platform string `json:"platform"`
+}
+
+type ServiceResponse struct {
+	Items     []Service `json:"items"`
+	Page      int       `json:"page"`
+	PageSize  int       `json:"pageSize"`
+	TotalHits int       `json:"totalHits"`
+}
+
+type EndpointResponse struct {
+	Items     []Endpoint `json:"items"`
+	Page      int        `json:"page"`
+	PageSize  int        `json:"pageSize"`
+	TotalHits int        `json:"totalHits"`
+}
+
+// AlertConfig is the subset of an application, website or global Smart Alert configuration needed to mute it.
+type AlertConfig struct {
+	Id              string              `json:"id"`
+	Name            string              `json:"name"`
+	Enabled         bool                `json:"enabled"`
+	ApplicationId   string              `json:"applicationId,omitempty"`
+	AlertChannelIds []string            `json:"alertChannelIds,omitempty"`
+	AlertChannels   map[string][]string `json:"alertChannels,omitempty"`
+}